@@ -0,0 +1,121 @@
+// Команда import засчитывает выполнения заданий из CSV или JSONL файла.
+//
+//	go run ./cmd/import -file completions.csv -dry-run
+//
+// Правила те же, что и у POST /admin/task-completions/import.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"service-boilerplate-go/internal/pkg/taskimport"
+	"service-boilerplate-go/internal/service"
+	"service-boilerplate-go/internal/service/entities"
+	"service-boilerplate-go/internal/storage"
+	"service-boilerplate-go/pkg/config"
+	"service-boilerplate-go/pkg/logger"
+	"service-boilerplate-go/pkg/pgdb"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// флаги объявляются до config.Load, который разбирает командную строку
+	file := flag.String("file", "", "Path to CSV or JSONL file with task completions")
+	formatFlag := flag.String("format", "", "File format: csv or jsonl (detected by extension by default)")
+	dryRun := flag.Bool("dry-run", false, "Validate rows without crediting")
+	batchSize := flag.Int("batch-size", 0, "Rows applied per transaction")
+
+	logger := logger.New()
+
+	appConfig, err := config.Load()
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to load config %s", err))
+	}
+
+	if *file == "" {
+		logger.Fatal(ctx, "file is required")
+	}
+
+	if *formatFlag == "" {
+		*formatFlag = *file
+	}
+	format, err := taskimport.ParseFormat(*formatFlag)
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to detect format %s", err))
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to open file %s", err))
+	}
+	rows, err := taskimport.Parse(f, format)
+	_ = f.Close()
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to parse file %s", err))
+	}
+
+	pgdbClient, err := pgdb.New(ctx, appConfig.Postgres())
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to initialize pgdb client %s", err))
+	}
+	defer pgdbClient.Close()
+
+	storageInstance := storage.New(logger, pgdbClient)
+	txManager := pgdb.NewTxManager(pgdbClient)
+	usersService := service.New(
+		storageInstance,
+		txManager,
+		appConfig.Auth().Secret(),
+		service.WithImportBatchSize(*batchSize),
+		// к начислениям импорта применяются те же сгорание, комиссии и проверка приглашений, что и в сервисе
+		service.WithPointsExpiry(appConfig.Expiry().Days(), appConfig.Expiry().Warning()),
+		service.WithReferralCommissions(appConfig.Referrals().CommissionRates()),
+		service.WithFraudScoring(
+			appConfig.Fraud().ScoreThreshold(),
+			appConfig.Fraud().BurstWindow(),
+			appConfig.Fraud().BurstSignups(),
+			appConfig.Fraud().IdleRatio(),
+		),
+	)
+
+	report, err := usersService.ImportTaskCompletions(ctx, rows, *dryRun)
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("import failed %s", err))
+	}
+
+	printReport(report)
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport выводит построчный отчёт и итог
+func printReport(report *entities.TaskImportReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LINE\tSTATUS\tUSER\tTASK\tERROR")
+	for _, row := range report.Rows {
+		user, task := "-", "-"
+		if row.UserID != nil {
+			user = row.UserID.String()
+		}
+		if row.TaskID != nil {
+			task = row.TaskID.String()
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", row.Line, row.Status, user, task, row.Error)
+	}
+	_ = w.Flush()
+
+	fmt.Printf(
+		"\ntotal: %d, valid: %d, completed: %d, skipped: %d, failed: %d, dry-run: %t, applied: %t\n",
+		report.Total, report.Valid, report.Completed, report.Skipped, report.Failed, report.DryRun, report.Applied,
+	)
+}
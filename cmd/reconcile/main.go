@@ -0,0 +1,84 @@
+// Команда reconcile сверяет балансы пользователей с журналом баллов.
+//
+//	go run ./cmd/reconcile -fix
+//
+// Правила те же, что и у POST /admin/points-reconciliation.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"service-boilerplate-go/internal/service"
+	"service-boilerplate-go/internal/service/entities"
+	"service-boilerplate-go/internal/storage"
+	"service-boilerplate-go/pkg/config"
+	"service-boilerplate-go/pkg/logger"
+	"service-boilerplate-go/pkg/pgdb"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// флаги объявляются до config.Load, который разбирает командную строку
+	fix := flag.Bool("fix", false, "Post missing ledger entries and correct balances to the expected values")
+
+	logger := logger.New()
+
+	appConfig, err := config.Load()
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to load config %s", err))
+	}
+
+	pgdbClient, err := pgdb.New(ctx, appConfig.Postgres())
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("failed to initialize pgdb client %s", err))
+	}
+	defer pgdbClient.Close()
+
+	storageInstance := storage.New(logger, pgdbClient)
+	txManager := pgdb.NewTxManager(pgdbClient)
+	usersService := service.New(
+		storageInstance,
+		txManager,
+		appConfig.Auth().Secret(),
+		// к исправляющим начислениям применяются те же сроки сгорания, что и в сервисе;
+		// комиссии с них не платятся
+		service.WithPointsExpiry(appConfig.Expiry().Days(), appConfig.Expiry().Warning()),
+	)
+
+	report, err := usersService.ReconcilePoints(ctx, *fix)
+	if err != nil {
+		logger.Fatal(ctx, fmt.Sprintf("reconciliation failed %s", err))
+	}
+
+	printReport(report)
+
+	for _, m := range report.Mismatches {
+		if !m.Fixed {
+			os.Exit(1)
+		}
+	}
+}
+
+// printReport выводит найденные расхождения и итог
+func printReport(report *entities.ReconciliationReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ISSUE\tUSER\tREFERENCE\tEXPECTED\tACTUAL\tFIXED")
+	for _, m := range report.Mismatches {
+		reference := "-"
+		if m.ReferenceID != nil {
+			reference = m.ReferenceID.String()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%t\n", m.Issue, m.UserID, reference, m.Expected, m.Actual, m.Fixed)
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\nmismatches: %d, fix: %t, fixed users: %d\n", len(report.Mismatches), report.Fix, report.FixedUsers)
+}
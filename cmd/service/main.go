@@ -4,18 +4,51 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"service-boilerplate-go/internal/pkg/middleware/adminauth"
 	"service-boilerplate-go/internal/pkg/middleware/recovery"
 	"service-boilerplate-go/internal/service"
 
+	"service-boilerplate-go/internal/api/admin_multiplier_events_get"
+	"service-boilerplate-go/internal/api/admin_multiplier_events_post"
+	"service-boilerplate-go/internal/api/admin_partners_partner_users_external_user_id_put"
+	"service-boilerplate-go/internal/api/admin_points_adjustments_post"
+	"service-boilerplate-go/internal/api/admin_points_reconciliation_post"
+	"service-boilerplate-go/internal/api/admin_redemptions_get"
+	"service-boilerplate-go/internal/api/admin_redemptions_id_cancel_post"
+	"service-boilerplate-go/internal/api/admin_redemptions_id_fulfil_post"
+	"service-boilerplate-go/internal/api/admin_referral_reviews_get"
+	"service-boilerplate-go/internal/api/admin_referral_reviews_id_approve_post"
+	"service-boilerplate-go/internal/api/admin_referral_reviews_id_reject_post"
+	"service-boilerplate-go/internal/api/admin_rewards_post"
+	"service-boilerplate-go/internal/api/admin_task_completions_import_post"
+	"service-boilerplate-go/internal/api/admin_tasks_id_translations_get"
+	"service-boilerplate-go/internal/api/admin_tasks_id_translations_locale_delete"
+	"service-boilerplate-go/internal/api/admin_tasks_id_translations_locale_put"
+	"service-boilerplate-go/internal/api/admin_user_tasks_id_revoke_post"
+	"service-boilerplate-go/internal/api/referral_codes_code_get"
+	"service-boilerplate-go/internal/api/rewards_get"
+	"service-boilerplate-go/internal/api/tasks_get"
 	"service-boilerplate-go/internal/api/users_auth_post"
+	"service-boilerplate-go/internal/api/users_id_badges_get"
+	"service-boilerplate-go/internal/api/users_id_points_history_get"
+	"service-boilerplate-go/internal/api/users_id_redemptions_get"
+	"service-boilerplate-go/internal/api/users_id_redemptions_post"
+	"service-boilerplate-go/internal/api/users_id_referral_code_put"
+	"service-boilerplate-go/internal/api/users_id_referrals_get"
+	"service-boilerplate-go/internal/api/users_id_referrals_tree_get"
 	"service-boilerplate-go/internal/api/users_id_referrer_post"
 	"service-boilerplate-go/internal/api/users_id_status_get"
 	"service-boilerplate-go/internal/api/users_id_task_complete_post"
+	"service-boilerplate-go/internal/api/users_id_task_progress_post"
+	"service-boilerplate-go/internal/api/users_id_transfers_post"
+	"service-boilerplate-go/internal/api/users_leaderboard_around_id_get"
 	"service-boilerplate-go/internal/api/users_leaderboard_get"
+	"service-boilerplate-go/internal/api/webhooks_partner_task_completions_post"
 	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
 	"service-boilerplate-go/internal/storage"
 	"service-boilerplate-go/pkg/config"
@@ -25,6 +58,9 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// defaultPointsExpiryInterval — период запуска сгорания баллов, если он не задан в конфиге
+const defaultPointsExpiryInterval = time.Hour
+
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -43,8 +79,33 @@ func main() {
 	defer pgdbClient.Close()
 
 	storageInstance := storage.New(logger, pgdbClient)
-	usersService := service.New(storageInstance, appConfig.Auth().Secret())
-	httpRouter := NewRouter(logger, usersService, appConfig.Auth().Secret())
+	txManager := pgdb.NewTxManager(pgdbClient)
+	usersService := service.New(
+		storageInstance,
+		txManager,
+		appConfig.Auth().Secret(),
+		service.WithDefaultLocale(appConfig.Locale().Default()),
+		service.WithWebhookSecrets(appConfig.Webhooks().PartnerSecrets()),
+		service.WithTransferLimits(appConfig.Transfers().DailyLimit(), appConfig.Transfers().MinAccountAge()),
+		service.WithPointsExpiry(appConfig.Expiry().Days(), appConfig.Expiry().Warning()),
+		service.WithReferralRewards(
+			appConfig.Referrals().RefereeBonus(),
+			appConfig.Referrals().ReferrerBonus(),
+			appConfig.Referrals().ReferrerAfterTasks(),
+		),
+		service.WithReferralCommissions(appConfig.Referrals().CommissionRates()),
+		service.WithOlderReferrerRequired(appConfig.Referrals().RequireOlderReferrer()),
+		service.WithFraudScoring(
+			appConfig.Fraud().ScoreThreshold(),
+			appConfig.Fraud().BurstWindow(),
+			appConfig.Fraud().BurstSignups(),
+			appConfig.Fraud().IdleRatio(),
+		),
+		service.WithLeaderboardLocation(appConfig.Leaderboard().Location()),
+	)
+	go runPointsExpiry(ctx, usersService, logger, appConfig.Expiry().Interval())
+
+	httpRouter := NewRouter(logger, usersService, appConfig.Auth().Secret(), appConfig.Fraud().TrustedProxies())
 
 	server := NewServer(appConfig.Server(), httpRouter)
 
@@ -90,26 +151,86 @@ func runServerWithGracefulShutdown(
 	return nil
 }
 
-func NewRouter(logger *logger.Logger, usersService *service.Service, secret string) http.Handler {
+// runPointsExpiry периодически списывает сгоревшие баллы, пока не отменён ctx
+func runPointsExpiry(ctx context.Context, usersService *service.Service, logger *logger.Logger, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultPointsExpiryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := usersService.ExpirePoints(ctx)
+			if err != nil {
+				logger.Error(ctx, fmt.Sprintf("points expiry failed: %s", err))
+				continue
+			}
+			if expired > 0 {
+				logger.Info(ctx, fmt.Sprintf("expired %d points", expired))
+			}
+		}
+	}
+}
+
+func NewRouter(logger *logger.Logger, usersService *service.Service, secret string, trustedProxies []netip.Prefix) http.Handler {
 	router := mux.NewRouter()
 	router.Use(logger.Middleware())
 	router.Use(recovery.Middleware(logger))
 
-	router.Handle("/users/auth", users_auth_post.New(logger, usersService)).Methods(http.MethodPost)
+	router.Handle("/users/auth", users_auth_post.New(logger, usersService, trustedProxies)).Methods(http.MethodPost)
+	// доставки партнёров аутентифицируются подписью, а не JWT
+	router.Handle("/webhooks/{partner}/task-completions", webhooks_partner_task_completions_post.New(logger, usersService)).Methods(http.MethodPost)
 
 	authenticated := router.NewRoute().Subrouter()
 	authenticated.Use(jwtauth.Middleware(secret))
 
 	authenticated.Handle("/users/{id}/status", users_id_status_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/{id}/badges", users_id_badges_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/{id}/points/history", users_id_points_history_get.New(logger, usersService)).Methods(http.MethodGet)
 	authenticated.Handle("/users/leaderboard", users_leaderboard_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/leaderboard/around/{id}", users_leaderboard_around_id_get.New(logger, usersService)).Methods(http.MethodGet)
 	authenticated.Handle("/users/{id}/task/complete", users_id_task_complete_post.New(logger, usersService)).Methods(http.MethodPost)
+	authenticated.Handle("/users/{id}/task/progress", users_id_task_progress_post.New(logger, usersService)).Methods(http.MethodPost)
 	authenticated.Handle("/users/{id}/referrer", users_id_referrer_post.New(logger, usersService)).Methods(http.MethodPost)
+	authenticated.Handle("/users/{id}/referral-code", users_id_referral_code_put.New(logger, usersService)).Methods(http.MethodPut)
+	authenticated.Handle("/users/{id}/referrals", users_id_referrals_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/{id}/referrals/tree", users_id_referrals_tree_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/referral-codes/{code}", referral_codes_code_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/tasks", tasks_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/rewards", rewards_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/{id}/redemptions", users_id_redemptions_get.New(logger, usersService)).Methods(http.MethodGet)
+	authenticated.Handle("/users/{id}/redemptions", users_id_redemptions_post.New(logger, usersService)).Methods(http.MethodPost)
+	authenticated.Handle("/users/{id}/transfers", users_id_transfers_post.New(logger, usersService)).Methods(http.MethodPost)
+
+	admin := authenticated.PathPrefix("/admin").Subrouter()
+	admin.Use(adminauth.Middleware(logger, usersService))
+
+	admin.Handle("/tasks/{id}/translations", admin_tasks_id_translations_get.New(logger, usersService)).Methods(http.MethodGet)
+	admin.Handle("/tasks/{id}/translations/{locale}", admin_tasks_id_translations_locale_put.New(logger, usersService)).Methods(http.MethodPut)
+	admin.Handle("/tasks/{id}/translations/{locale}", admin_tasks_id_translations_locale_delete.New(logger, usersService)).Methods(http.MethodDelete)
+	admin.Handle("/multiplier-events", admin_multiplier_events_get.New(logger, usersService)).Methods(http.MethodGet)
+	admin.Handle("/multiplier-events", admin_multiplier_events_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/user-tasks/{id}/revoke", admin_user_tasks_id_revoke_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/partners/{partner}/users/{external_user_id}", admin_partners_partner_users_external_user_id_put.New(logger, usersService)).Methods(http.MethodPut)
+	admin.Handle("/task-completions/import", admin_task_completions_import_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/rewards", admin_rewards_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/redemptions", admin_redemptions_get.New(logger, usersService)).Methods(http.MethodGet)
+	admin.Handle("/redemptions/{id}/fulfil", admin_redemptions_id_fulfil_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/redemptions/{id}/cancel", admin_redemptions_id_cancel_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/points-adjustments", admin_points_adjustments_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/points-reconciliation", admin_points_reconciliation_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/referral-reviews", admin_referral_reviews_get.New(logger, usersService)).Methods(http.MethodGet)
+	admin.Handle("/referral-reviews/{id}/approve", admin_referral_reviews_id_approve_post.New(logger, usersService)).Methods(http.MethodPost)
+	admin.Handle("/referral-reviews/{id}/reject", admin_referral_reviews_id_reject_post.New(logger, usersService)).Methods(http.MethodPost)
 
 	return router
 }
 
-// TODO: txManager
-
 func NewServer(config config.Server, handler http.Handler) *http.Server {
 	return &http.Server{
 		Addr:    fmt.Sprintf("%s:%s", config.Host(), config.Port()),
@@ -0,0 +1,66 @@
+package admin_multiplier_events_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ListMultiplierEvents(ctx context.Context) ([]*entities.MultiplierEvent, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	events, err := h.service.ListMultiplierEvents(ctx)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to list multiplier events")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.MultiplierEvent, len(events))
+	for i, e := range events {
+		resp[i] = api.MultiplierEvent{
+			Id:         e.ID,
+			Name:       e.Name,
+			Multiplier: e.Multiplier,
+			StartsAt:   e.StartsAt,
+			EndsAt:     e.EndsAt,
+			TaskIds:    e.TaskIDs,
+			Categories: e.Categories,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "multiplier events retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
@@ -0,0 +1,115 @@
+package admin_multiplier_events_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	CreateMultiplierEvent(ctx context.Context, event entities.MultiplierEvent) (*entities.MultiplierEvent, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.MultiplierEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"name":       req.Name,
+		"multiplier": req.Multiplier,
+		"starts_at":  req.StartsAt,
+		"ends_at":    req.EndsAt,
+	})
+
+	if strings.TrimSpace(req.Name) == "" {
+		h.logger.Warn(ctx, "empty event name")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if !entities.IsValidMultiplier(req.Multiplier) {
+		h.logger.Warn(ctx, "multiplier must be positive, at most 9999.99 and have at most 2 decimal places")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if !req.EndsAt.After(req.StartsAt) {
+		h.logger.Warn(ctx, "event ends before it starts")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	event := entities.MultiplierEvent{
+		Name:       req.Name,
+		Multiplier: req.Multiplier,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		TaskIDs:    []uuid.UUID{},
+		Categories: []string{},
+	}
+	if req.TaskIds != nil {
+		event.TaskIDs = *req.TaskIds
+	}
+	if req.Categories != nil {
+		event.Categories = *req.Categories
+	}
+
+	created, err := h.service.CreateMultiplierEvent(ctx, event)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to create multiplier event")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"event_id": created.ID,
+	})
+	h.logger.Info(ctx, "multiplier event created successfully")
+
+	response.OkJSON(w, api.MultiplierEvent{
+		Id:         created.ID,
+		Name:       created.Name,
+		Multiplier: created.Multiplier,
+		StartsAt:   created.StartsAt,
+		EndsAt:     created.EndsAt,
+		TaskIds:    created.TaskIDs,
+		Categories: created.Categories,
+		CreatedAt:  created.CreatedAt,
+	})
+}
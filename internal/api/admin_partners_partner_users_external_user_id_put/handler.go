@@ -0,0 +1,96 @@
+package admin_partners_partner_users_external_user_id_put
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	IsKnownPartner(partner string) bool
+	LinkPartnerUser(ctx context.Context, link entities.PartnerUserLink) (*entities.PartnerUserLink, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	partner, externalUserID := vars["partner"], vars["external_user_id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"partner":          partner,
+		"external_user_id": externalUserID,
+	})
+
+	if !h.service.IsKnownPartner(partner) {
+		h.logger.Warn(ctx, "unknown partner")
+		response.ErrorDomain(w, entities.ErrUnknownPartner)
+		return
+	}
+
+	var req api.PartnerUserLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.UserId == uuid.Nil {
+		h.logger.Warn(ctx, "empty user id")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id": req.UserId,
+	})
+
+	link, err := h.service.LinkPartnerUser(ctx, entities.PartnerUserLink{
+		Partner:        partner,
+		ExternalUserID: externalUserID,
+		UserID:         req.UserId,
+	})
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to link partner user")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	h.logger.Info(ctx, "partner user linked successfully")
+
+	response.OkJSON(w, api.PartnerUserLink{
+		Partner:        link.Partner,
+		ExternalUserId: link.ExternalUserID,
+		UserId:         link.UserID,
+		CreatedAt:      link.CreatedAt,
+	})
+}
@@ -0,0 +1,172 @@
+package admin_points_adjustments_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxAdjustments — максимальное число корректировок в одном запросе
+	maxAdjustments = 1000
+	// maxIdempotencyKeyLength — максимальная длина ключа идемпотентности
+	maxIdempotencyKeyLength = 255
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	AdjustPoints(ctx context.Context, batch entities.PointsAdjustmentBatch) (*entities.PointsAdjustmentBatch, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем ID администратора из JWT
+	adminIDStr, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		h.logger.Warn(ctx, "unauthorized: invalid user id in token")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"admin_id":        adminID,
+		"idempotency_key": key,
+	})
+
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		h.logger.Warn(ctx, "missing or too long idempotency key")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.PointsAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"adjustments": len(req.Adjustments),
+	})
+
+	if len(req.Adjustments) == 0 || len(req.Adjustments) > maxAdjustments {
+		h.logger.Warn(ctx, "invalid number of adjustments")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	batch := entities.PointsAdjustmentBatch{
+		IdempotencyKey: key,
+		AdminID:        adminID,
+		Adjustments:    make([]entities.PointsAdjustment, len(req.Adjustments)),
+	}
+	for i, item := range req.Adjustments {
+		note := strings.TrimSpace(item.Note)
+
+		if item.UserId == uuid.Nil || item.Amount == 0 || note == "" {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"position": i,
+			})
+			h.logger.Warn(ctx, "adjustment requires user id, non-zero amount and note")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+
+		reason := entities.AdjustmentReason(item.ReasonCode)
+		switch reason {
+		case entities.AdjustmentReasonCompensation, entities.AdjustmentReasonCorrection,
+			entities.AdjustmentReasonGoodwill, entities.AdjustmentReasonFraud:
+		default:
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"position":    i,
+				"reason_code": item.ReasonCode,
+			})
+			h.logger.Warn(ctx, "invalid reason code")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+
+		batch.Adjustments[i] = entities.PointsAdjustment{
+			UserID:     item.UserId,
+			Amount:     item.Amount,
+			ReasonCode: reason,
+			Note:       note,
+		}
+	}
+
+	result, err := h.service.AdjustPoints(ctx, batch)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to adjust points")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"replayed": result.Replayed,
+	})
+	h.logger.Info(ctx, "points adjusted successfully")
+
+	response.OkJSON(w, mapAdjustmentBatchToDTO(result))
+}
+
+// mapAdjustmentBatchToDTO конвертирует entities.PointsAdjustmentBatch в api.PointsAdjustmentBatch
+func mapAdjustmentBatchToDTO(b *entities.PointsAdjustmentBatch) api.PointsAdjustmentBatch {
+	adjustments := make([]api.PointsAdjustment, len(b.Adjustments))
+	for i, a := range b.Adjustments {
+		adjustments[i] = api.PointsAdjustment{
+			Id:         a.ID,
+			UserId:     a.UserID,
+			AdminId:    a.AdminID,
+			Amount:     a.Amount,
+			ReasonCode: api.AdjustmentReason(a.ReasonCode),
+			Note:       a.Note,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+
+	return api.PointsAdjustmentBatch{
+		IdempotencyKey: b.IdempotencyKey,
+		Replayed:       b.Replayed,
+		Adjustments:    adjustments,
+	}
+}
@@ -0,0 +1,94 @@
+package admin_points_reconciliation_post
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ReconcilePoints(ctx context.Context, fix bool) (*entities.ReconciliationReport, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fix := false
+	if f := r.URL.Query().Get("fix"); f != "" {
+		v, err := strconv.ParseBool(f)
+		if err != nil {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"fix_param": f,
+				"error":     err.Error(),
+			})
+			h.logger.Warn(ctx, "invalid fix parameter")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+		fix = v
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"fix": fix,
+	})
+
+	report, err := h.service.ReconcilePoints(ctx, fix)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to reconcile points")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"mismatches":  len(report.Mismatches),
+		"fixed_users": report.FixedUsers,
+	})
+	h.logger.Info(ctx, "points reconciled successfully")
+
+	response.OkJSON(w, mapReportToDTO(report))
+}
+
+// mapReportToDTO конвертирует entities.ReconciliationReport в api.ReconciliationReport
+func mapReportToDTO(report *entities.ReconciliationReport) api.ReconciliationReport {
+	mismatches := make([]api.ReconciliationMismatch, len(report.Mismatches))
+	for i, m := range report.Mismatches {
+		mismatches[i] = api.ReconciliationMismatch{
+			Issue:       api.ReconciliationMismatchIssue(m.Issue),
+			UserId:      m.UserID,
+			ReferenceId: m.ReferenceID,
+			Expected:    m.Expected,
+			Actual:      m.Actual,
+			Fixed:       m.Fixed,
+		}
+	}
+
+	return api.ReconciliationReport{
+		Fix:        report.Fix,
+		FixedUsers: report.FixedUsers,
+		Mismatches: mismatches,
+	}
+}
@@ -0,0 +1,93 @@
+package admin_redemptions_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ListRedemptions(ctx context.Context, filter entities.RedemptionFilter) ([]*entities.Redemption, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := entities.RedemptionFilter{
+		Status: entities.RedemptionStatus(r.URL.Query().Get("status")),
+	}
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"status": filter.Status,
+	})
+
+	switch filter.Status {
+	case "", entities.RedemptionStatusPending, entities.RedemptionStatusFulfilled, entities.RedemptionStatusCancelled:
+	default:
+		h.logger.Warn(ctx, "invalid status parameter")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	redemptions, err := h.service.ListRedemptions(ctx, filter)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to list redemptions")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.Redemption, len(redemptions))
+	for i, rd := range redemptions {
+		resp[i] = mapRedemptionToDTO(rd)
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "redemptions retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
+
+// mapRedemptionToDTO конвертирует entities.Redemption в api.Redemption
+func mapRedemptionToDTO(rd *entities.Redemption) api.Redemption {
+	var note *string
+	if rd.Note != "" {
+		note = &rd.Note
+	}
+
+	return api.Redemption{
+		Id:          rd.ID,
+		UserId:      rd.UserID,
+		RewardId:    rd.RewardID,
+		RewardCode:  rd.RewardCode,
+		Price:       rd.Price,
+		Status:      api.RedemptionStatus(rd.Status),
+		ProcessedBy: rd.ProcessedBy,
+		Note:        note,
+		CreatedAt:   rd.CreatedAt,
+		UpdatedAt:   rd.UpdatedAt,
+	}
+}
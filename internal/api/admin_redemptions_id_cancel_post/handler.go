@@ -0,0 +1,132 @@
+package admin_redemptions_id_cancel_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	DecideRedemption(ctx context.Context, decision entities.RedemptionDecision) (*entities.Redemption, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем ID администратора из JWT
+	adminIDStr, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		h.logger.Warn(ctx, "unauthorized: invalid user id in token")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	redemptionIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"redemption_id": redemptionIDStr,
+	})
+
+	redemptionID, err := uuid.Parse(redemptionIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid redemption id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	// тело необязательно
+	var req api.RedemptionDecisionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"error": err.Error(),
+			})
+			h.logger.Warn(ctx, "failed to decode json body")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	decision := entities.RedemptionDecision{
+		RedemptionID: redemptionID,
+		AdminID:      adminID,
+		Status:       entities.RedemptionStatusCancelled,
+	}
+	if req.Note != nil {
+		decision.Note = strings.TrimSpace(*req.Note)
+	}
+
+	redemption, err := h.service.DecideRedemption(ctx, decision)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to cancel redemption")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id": redemption.UserID,
+		"price":   redemption.Price,
+	})
+	h.logger.Info(ctx, "redemption cancelled successfully")
+
+	response.OkJSON(w, mapRedemptionToDTO(redemption))
+}
+
+// mapRedemptionToDTO конвертирует entities.Redemption в api.Redemption
+func mapRedemptionToDTO(rd *entities.Redemption) api.Redemption {
+	var note *string
+	if rd.Note != "" {
+		note = &rd.Note
+	}
+
+	return api.Redemption{
+		Id:          rd.ID,
+		UserId:      rd.UserID,
+		RewardId:    rd.RewardID,
+		RewardCode:  rd.RewardCode,
+		Price:       rd.Price,
+		Status:      api.RedemptionStatus(rd.Status),
+		ProcessedBy: rd.ProcessedBy,
+		Note:        note,
+		CreatedAt:   rd.CreatedAt,
+		UpdatedAt:   rd.UpdatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package admin_referral_reviews_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ListReferralReviews(ctx context.Context, filter entities.ReferralReviewFilter) ([]*entities.ReferralReview, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := entities.ReferralReviewFilter{
+		Status: entities.ReferralReviewStatus(r.URL.Query().Get("status")),
+	}
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"status": filter.Status,
+	})
+
+	switch filter.Status {
+	case "", entities.ReferralReviewStatusPending, entities.ReferralReviewStatusApproved, entities.ReferralReviewStatusRejected:
+	default:
+		h.logger.Warn(ctx, "invalid status parameter")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	reviews, err := h.service.ListReferralReviews(ctx, filter)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to list referral reviews")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.ReferralReview, len(reviews))
+	for i, rv := range reviews {
+		resp[i] = mapReferralReviewToDTO(rv)
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "referral reviews retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
+
+// mapReferralReviewToDTO конвертирует entities.ReferralReview в api.ReferralReview
+func mapReferralReviewToDTO(rv *entities.ReferralReview) api.ReferralReview {
+	var note *string
+	if rv.Note != "" {
+		note = &rv.Note
+	}
+
+	signals := make([]string, len(rv.Signals))
+	for i, signal := range rv.Signals {
+		signals[i] = string(signal)
+	}
+
+	return api.ReferralReview{
+		Id:         rv.ID,
+		RefereeId:  rv.RefereeID,
+		ReferrerId: rv.ReferrerID,
+		Trigger:    api.ReferralReviewTrigger(rv.Trigger),
+		Score:      rv.Score,
+		Signals:    signals,
+		Status:     api.ReferralReviewStatus(rv.Status),
+		ReviewedBy: rv.ReviewedBy,
+		Note:       note,
+		CreatedAt:  rv.CreatedAt,
+		ReviewedAt: rv.ReviewedAt,
+	}
+}
@@ -0,0 +1,138 @@
+package admin_referral_reviews_id_reject_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	DecideReferralReview(ctx context.Context, decision entities.ReferralReviewDecision) (*entities.ReferralReview, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем ID администратора из JWT
+	adminIDStr, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		h.logger.Warn(ctx, "unauthorized: invalid user id in token")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	reviewIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"review_id": reviewIDStr,
+	})
+
+	reviewID, err := uuid.Parse(reviewIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid review id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	// тело необязательно
+	var req api.ReferralReviewDecisionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"error": err.Error(),
+			})
+			h.logger.Warn(ctx, "failed to decode json body")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	decision := entities.ReferralReviewDecision{
+		ReviewID: reviewID,
+		AdminID:  adminID,
+		Status:   entities.ReferralReviewStatusRejected,
+	}
+	if req.Note != nil {
+		decision.Note = strings.TrimSpace(*req.Note)
+	}
+
+	review, err := h.service.DecideReferralReview(ctx, decision)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to reject referral")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"referee_id":  review.RefereeID,
+		"referrer_id": review.ReferrerID,
+	})
+	h.logger.Info(ctx, "referral rejected successfully")
+
+	response.OkJSON(w, mapReferralReviewToDTO(review))
+}
+
+// mapReferralReviewToDTO конвертирует entities.ReferralReview в api.ReferralReview
+func mapReferralReviewToDTO(rv *entities.ReferralReview) api.ReferralReview {
+	var note *string
+	if rv.Note != "" {
+		note = &rv.Note
+	}
+
+	signals := make([]string, len(rv.Signals))
+	for i, signal := range rv.Signals {
+		signals[i] = string(signal)
+	}
+
+	return api.ReferralReview{
+		Id:         rv.ID,
+		RefereeId:  rv.RefereeID,
+		ReferrerId: rv.ReferrerID,
+		Trigger:    api.ReferralReviewTrigger(rv.Trigger),
+		Score:      rv.Score,
+		Signals:    signals,
+		Status:     api.ReferralReviewStatus(rv.Status),
+		ReviewedBy: rv.ReviewedBy,
+		Note:       note,
+		CreatedAt:  rv.CreatedAt,
+		ReviewedAt: rv.ReviewedAt,
+	}
+}
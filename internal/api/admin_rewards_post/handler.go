@@ -0,0 +1,117 @@
+package admin_rewards_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	CreateReward(ctx context.Context, reward entities.Reward) (*entities.Reward, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.RewardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"code":  req.Code,
+		"price": req.Price,
+	})
+
+	if strings.TrimSpace(req.Code) == "" || strings.TrimSpace(req.Title) == "" {
+		h.logger.Warn(ctx, "empty reward code or title")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.Price <= 0 ||
+		(req.Stock != nil && *req.Stock < 0) ||
+		(req.PerUserLimit != nil && *req.PerUserLimit <= 0) {
+		h.logger.Warn(ctx, "invalid reward price, stock or limit")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	reward := entities.Reward{
+		Code:         strings.TrimSpace(req.Code),
+		Title:        strings.TrimSpace(req.Title),
+		Price:        req.Price,
+		Stock:        req.Stock,
+		PerUserLimit: req.PerUserLimit,
+		Active:       true,
+	}
+	if req.Description != nil {
+		reward.Description = *req.Description
+	}
+	if req.Active != nil {
+		reward.Active = *req.Active
+	}
+
+	created, err := h.service.CreateReward(ctx, reward)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to create reward")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"reward_id": created.ID,
+	})
+	h.logger.Info(ctx, "reward created successfully")
+
+	response.OkJSON(w, mapRewardToDTO(created))
+}
+
+// mapRewardToDTO конвертирует entities.Reward в api.Reward
+func mapRewardToDTO(rw *entities.Reward) api.Reward {
+	var desc *string
+	if rw.Description != "" {
+		desc = &rw.Description
+	}
+
+	return api.Reward{
+		Id:           rw.ID,
+		Code:         rw.Code,
+		Title:        rw.Title,
+		Description:  desc,
+		Price:        rw.Price,
+		Stock:        rw.Stock,
+		PerUserLimit: rw.PerUserLimit,
+		Active:       rw.Active,
+	}
+}
@@ -0,0 +1,139 @@
+package admin_task_completions_import_post
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/pkg/taskimport"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+// maxBodySize ограничивает размер загружаемого файла
+const maxBodySize = 10 << 20
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ImportTaskCompletions(ctx context.Context, rows []entities.TaskImportRow, dryRun bool) (*entities.TaskImportReport, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dryRun := false
+	if d := r.URL.Query().Get("dry_run"); d != "" {
+		v, err := strconv.ParseBool(d)
+		if err != nil {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"dry_run_param": d,
+				"error":         err.Error(),
+			})
+			h.logger.Warn(ctx, "invalid dry_run parameter")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+		dryRun = v
+	}
+
+	// формат из query имеет приоритет над Content-Type
+	formatParam := r.URL.Query().Get("format")
+	if formatParam == "" {
+		formatParam = r.Header.Get("Content-Type")
+	}
+
+	format, err := taskimport.ParseFormat(formatParam)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"format": formatParam,
+			"error":  err.Error(),
+		})
+		h.logger.Warn(ctx, "unsupported import format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"dry_run": dryRun,
+		"format":  format,
+	})
+
+	rows, err := taskimport.Parse(http.MaxBytesReader(w, r.Body, maxBodySize), format)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to parse import file")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"rows": len(rows),
+	})
+
+	report, err := h.service.ImportTaskCompletions(ctx, rows, dryRun)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to import task completions")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"applied":   report.Applied,
+		"completed": report.Completed,
+		"skipped":   report.Skipped,
+		"failed":    report.Failed,
+	})
+	h.logger.Info(ctx, "task completions import processed")
+
+	response.OkJSON(w, mapReportToDTO(report))
+}
+
+// mapReportToDTO конвертирует отчёт импорта в api.TaskImportReport
+func mapReportToDTO(report *entities.TaskImportReport) api.TaskImportReport {
+	rows := make([]api.TaskImportRowResult, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = api.TaskImportRowResult{
+			Line:       row.Line,
+			Status:     api.TaskImportRowResultStatus(row.Status),
+			UserId:     row.UserID,
+			TaskId:     row.TaskID,
+			UserTaskId: row.UserTaskID,
+		}
+		if row.Error != "" {
+			rows[i].Error = &row.Error
+		}
+	}
+
+	return api.TaskImportReport{
+		DryRun:    report.DryRun,
+		Applied:   report.Applied,
+		Total:     report.Total,
+		Valid:     report.Valid,
+		Completed: report.Completed,
+		Skipped:   report.Skipped,
+		Failed:    report.Failed,
+		Rows:      rows,
+	}
+}
@@ -0,0 +1,86 @@
+package admin_tasks_id_translations_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetTaskTranslations(ctx context.Context, taskID uuid.UUID) ([]entities.TaskTranslation, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"task_id": taskIDStr,
+	})
+
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid task id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	translations, err := h.service.GetTaskTranslations(ctx, taskID)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get task translations")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.TaskTranslation, len(translations))
+	for i, tr := range translations {
+		var desc *string
+		if tr.Description != "" {
+			desc = &tr.Description
+		}
+
+		resp[i] = api.TaskTranslation{
+			TaskId:      tr.TaskID,
+			Locale:      tr.Locale,
+			Title:       tr.Title,
+			Description: desc,
+			UpdatedAt:   tr.UpdatedAt,
+		}
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "task translations retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
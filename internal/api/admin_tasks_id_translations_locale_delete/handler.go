@@ -0,0 +1,74 @@
+package admin_tasks_id_translations_locale_delete
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/locale"
+	"service-boilerplate-go/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	DeleteTaskTranslation(ctx context.Context, taskID uuid.UUID, locale string) error
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	taskIDStr, localeStr := vars["id"], vars["locale"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"task_id": taskIDStr,
+		"locale":  localeStr,
+	})
+
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid task id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	tag, ok := locale.Normalize(localeStr)
+	if !ok {
+		h.logger.Warn(ctx, "invalid locale format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTaskTranslation(ctx, taskID, tag); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to delete task translation")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	h.logger.Info(ctx, "task translation deleted successfully")
+	response.OkJSON(w, api.StatusResponse{Status: "ok"})
+}
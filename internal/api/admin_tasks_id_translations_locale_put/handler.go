@@ -0,0 +1,115 @@
+package admin_tasks_id_translations_locale_put
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/locale"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	UpsertTaskTranslation(ctx context.Context, tr entities.TaskTranslation) (*entities.TaskTranslation, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	taskIDStr, localeStr := vars["id"], vars["locale"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"task_id": taskIDStr,
+		"locale":  localeStr,
+	})
+
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid task id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	tag, ok := locale.Normalize(localeStr)
+	if !ok {
+		h.logger.Warn(ctx, "invalid locale format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.TaskTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Title) == "" {
+		h.logger.Warn(ctx, "empty title")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	tr := entities.TaskTranslation{
+		TaskID: taskID,
+		Locale: tag,
+		Title:  req.Title,
+	}
+	if req.Description != nil {
+		tr.Description = *req.Description
+	}
+
+	saved, err := h.service.UpsertTaskTranslation(ctx, tr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to save task translation")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	h.logger.Info(ctx, "task translation saved successfully")
+
+	var desc *string
+	if saved.Description != "" {
+		desc = &saved.Description
+	}
+
+	response.OkJSON(w, api.TaskTranslation{
+		TaskId:      saved.TaskID,
+		Locale:      saved.Locale,
+		Title:       saved.Title,
+		Description: desc,
+		UpdatedAt:   saved.UpdatedAt,
+	})
+}
@@ -0,0 +1,138 @@
+package admin_user_tasks_id_revoke_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	RevokeTaskCompletion(ctx context.Context, revocation entities.TaskRevocation) (*entities.RevokedTaskCompletion, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем ID администратора из JWT
+	adminIDStr, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		h.logger.Warn(ctx, "unauthorized: invalid user id in token")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userTaskIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_task_id": userTaskIDStr,
+	})
+
+	userTaskID, err := uuid.Parse(userTaskIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user task id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.TaskRevocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		h.logger.Warn(ctx, "empty revocation reason")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	revocation := entities.TaskRevocation{
+		UserTaskID: userTaskID,
+		AdminID:    adminID,
+		Reason:     req.Reason,
+	}
+	if req.AllowRecomplete != nil {
+		revocation.AllowRecomplete = *req.AllowRecomplete
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"reason":           revocation.Reason,
+		"allow_recomplete": revocation.AllowRecomplete,
+	})
+
+	revoked, err := h.service.RevokeTaskCompletion(ctx, revocation)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to revoke task completion")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id":         revoked.UserID,
+		"task_id":         revoked.TaskID,
+		"points_deducted": revoked.Points,
+		"balance_after":   revoked.BalanceAfter,
+		"commissions":     revoked.CommissionsReversed,
+	})
+	if revoked.BalanceAfter < 0 {
+		h.logger.Warn(ctx, "task completion revoked, user balance is negative")
+	} else {
+		h.logger.Info(ctx, "task completion revoked successfully")
+	}
+
+	response.OkJSON(w, api.RevokedTask{
+		Id:                  revoked.ID,
+		UserId:              revoked.UserID,
+		TaskId:              revoked.TaskID,
+		PointsDeducted:      revoked.Points,
+		CompletedAt:         revoked.CompletedAt,
+		RevokedAt:           *revoked.RevokedAt,
+		RevokedBy:           revoked.RevokedBy,
+		Reason:              revoked.RevokeReason,
+		AllowRecomplete:     revoked.AllowRecomplete,
+		BalanceAfter:        revoked.BalanceAfter,
+		CommissionsReversed: revoked.CommissionsReversed,
+	})
+}
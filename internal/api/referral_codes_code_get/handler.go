@@ -0,0 +1,64 @@
+package referral_codes_code_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetUserByReferralCode(ctx context.Context, code string) (*entities.User, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	code := mux.Vars(r)["code"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"referral_code": code,
+	})
+
+	user, err := h.service.GetUserByReferralCode(ctx, code)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to resolve referral code")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id": user.ID,
+	})
+	h.logger.Info(ctx, "referral code resolved successfully")
+
+	// наружу отдаются только публичные поля владельца кода
+	response.OkJSON(w, api.ReferralProfile{
+		UserId:       user.ID,
+		Username:     user.Username,
+		ReferralCode: user.ReferralCode,
+	})
+}
@@ -0,0 +1,76 @@
+package rewards_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ListRewards(ctx context.Context) ([]*entities.Reward, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rewards, err := h.service.ListRewards(ctx)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to list rewards")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.Reward, len(rewards))
+	for i, rw := range rewards {
+		resp[i] = mapRewardToDTO(rw)
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "rewards retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
+
+// mapRewardToDTO конвертирует entities.Reward в api.Reward
+func mapRewardToDTO(rw *entities.Reward) api.Reward {
+	var desc *string
+	if rw.Description != "" {
+		desc = &rw.Description
+	}
+
+	return api.Reward{
+		Id:           rw.ID,
+		Code:         rw.Code,
+		Title:        rw.Title,
+		Description:  desc,
+		Price:        rw.Price,
+		Stock:        rw.Stock,
+		PerUserLimit: rw.PerUserLimit,
+		Active:       rw.Active,
+	}
+}
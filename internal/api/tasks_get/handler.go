@@ -0,0 +1,101 @@
+package tasks_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/locale"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	ListTasks(ctx context.Context, filter entities.TaskFilter, locales []string) ([]*entities.Task, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := entities.TaskFilter{
+		Category: r.URL.Query().Get("category"),
+		Tag:      r.URL.Query().Get("tag"),
+	}
+	locales := locale.FromRequest(r)
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"category": filter.Category,
+		"tag":      filter.Tag,
+		"locales":  locales,
+	})
+
+	tasks, err := h.service.ListTasks(ctx, filter, locales)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to list tasks")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	resp := make([]api.CatalogTask, len(tasks))
+	for i, t := range tasks {
+		resp[i] = mapTaskToDTO(t)
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(resp),
+	})
+	h.logger.Info(ctx, "tasks catalog retrieved successfully")
+
+	response.OkJSON(w, resp)
+}
+
+// mapTaskToDTO конвертирует entities.Task в api.CatalogTask
+func mapTaskToDTO(t *entities.Task) api.CatalogTask {
+	var desc *string
+	if t.Description != "" {
+		desc = &t.Description
+	}
+
+	var category *string
+	if t.Category != "" {
+		category = &t.Category
+	}
+
+	tags := t.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return api.CatalogTask{
+		Id:             t.ID,
+		Code:           t.Code,
+		Title:          t.Title,
+		Description:    desc,
+		RewardPoints:   t.RewardPoints,
+		TargetCount:    t.TargetCount,
+		Category:       category,
+		Tags:           tags,
+		CompletionCap:  t.CompletionCap,
+		RemainingSlots: t.RemainingSlots(),
+	}
+}
@@ -3,10 +3,15 @@ package users_auth_post
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
+	"unicode/utf8"
 
 	"service-boilerplate-go/internal/generated/api"
 	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
 
 	"github.com/google/uuid"
 )
@@ -20,16 +25,24 @@ type Logger interface {
 }
 
 type Service interface {
-	Auth(ctx context.Context, username, password string) (token string, userID uuid.UUID, err error)
+	Auth(ctx context.Context, username, password string, signup entities.SignupInfo) (token string, userID uuid.UUID, err error)
 }
 
+const (
+	// maxUserAgentLength — длина User-Agent, которая сохраняется при регистрации
+	maxUserAgentLength = 512
+	// maxUTMLength — максимальная длина полей атрибуции
+	maxUTMLength = 255
+)
+
 type Handler struct {
-	logger  Logger
-	service Service
+	logger         Logger
+	service        Service
+	trustedProxies []netip.Prefix // прокси, которым доверяется X-Forwarded-For
 }
 
-func New(logger Logger, service Service) *Handler {
-	return &Handler{logger: logger, service: service}
+func New(logger Logger, service Service, trustedProxies []netip.Prefix) *Handler {
+	return &Handler{logger: logger, service: service, trustedProxies: trustedProxies}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +74,41 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, userID, err := h.service.Auth(ctx, req.Username, req.Password)
+	signup := signupInfo(r, h.trustedProxies)
+
+	// реферер из ссылки приглашения указывается либо кодом, либо id
+	if req.ReferralCode != nil {
+		signup.ReferralCode = strings.TrimSpace(*req.ReferralCode)
+	}
+	if req.ReferrerId != nil && *req.ReferrerId != uuid.Nil {
+		signup.ReferrerID = req.ReferrerId
+	}
+	if signup.ReferralCode != "" && signup.ReferrerID != nil {
+		h.logger.Warn(ctx, "both referrer id and referral code are set")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.UtmSource != nil {
+		signup.UTMSource = strings.TrimSpace(*req.UtmSource)
+	}
+	if req.UtmCampaign != nil {
+		signup.UTMCampaign = strings.TrimSpace(*req.UtmCampaign)
+	}
+	if utf8.RuneCountInString(signup.UTMSource) > maxUTMLength || utf8.RuneCountInString(signup.UTMCampaign) > maxUTMLength {
+		h.logger.Warn(ctx, "utm attribution is too long")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"referral_code": signup.ReferralCode,
+		"referrer_id":   signup.ReferrerID,
+		"utm_source":    signup.UTMSource,
+		"utm_campaign":  signup.UTMCampaign,
+	})
+
+	token, userID, err := h.service.Auth(ctx, req.Username, req.Password, signup)
 	if err != nil {
 		ctx = h.logger.WithFields(ctx, map[string]any{
 			"error": err.Error(),
@@ -78,3 +125,56 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		UserId: userID,
 	})
 }
+
+// signupInfo извлекает IP и User-Agent запроса
+func signupInfo(r *http.Request, trustedProxies []netip.Prefix) entities.SignupInfo {
+	userAgent := []rune(r.UserAgent())
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return entities.SignupInfo{
+		IP:        clientIP(r, trustedProxies),
+		UserAgent: string(userAgent),
+	}
+}
+
+// clientIP возвращает адрес клиента. X-Forwarded-For читается, только если запрос пришёл
+// от доверенного прокси: берётся крайний справа адрес, не принадлежащий доверенным прокси.
+// Адреса левее клиент может подставить сам, поэтому дальше они не читаются.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+
+	client, err := netip.ParseAddr(remote)
+	if err != nil || !isTrustedProxy(client, trustedProxies) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop
+		if !isTrustedProxy(hop, trustedProxies) {
+			break
+		}
+	}
+
+	return client.String()
+}
+
+// isTrustedProxy сообщает, входит ли адрес в сети доверенных прокси
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
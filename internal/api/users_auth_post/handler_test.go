@@ -0,0 +1,96 @@
+package users_auth_post
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Error(context.Context, string) {}
+func (nopLogger) Warn(context.Context, string)  {}
+func (nopLogger) Info(context.Context, string)  {}
+
+func (nopLogger) WithFields(ctx context.Context, _ map[string]any) context.Context { return ctx }
+
+// signupRecorder запоминает данные регистрации, переданные сервису
+type signupRecorder struct {
+	signup entities.SignupInfo
+}
+
+func (r *signupRecorder) Auth(_ context.Context, _, _ string, signup entities.SignupInfo) (string, uuid.UUID, error) {
+	r.signup = signup
+	return "token", uuid.New(), nil
+}
+
+func TestServeHTTPSignupIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "untrusted peer cannot forge forwarded for",
+			remoteAddr: "203.0.113.7:41000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.2:41000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "client-supplied hops left of the client are ignored",
+			remoteAddr: "10.0.0.2:41000",
+			forwarded:  []string{"192.0.2.99, 198.51.100.1, 10.0.0.3"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "hops split across headers",
+			remoteAddr: "10.0.0.2:41000",
+			forwarded:  []string{"192.0.2.99", "198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.0.0.2:41000",
+			want:       "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &signupRecorder{}
+			h := New(nopLogger{}, service, trusted)
+
+			r := httptest.NewRequest(http.MethodPost, "/users/auth", strings.NewReader(`{"username":"alice","password":"secret"}`))
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if service.signup.IP != tt.want {
+				t.Fatalf("signup IP = %q, want %q", service.signup.IP, tt.want)
+			}
+		})
+	}
+}
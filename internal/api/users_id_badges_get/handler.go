@@ -0,0 +1,108 @@
+package users_id_badges_get
+
+import (
+	"context"
+	"net/http"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetUserBadges(ctx context.Context, userID uuid.UUID) ([]entities.UserBadge, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	badges, err := h.service.GetUserBadges(ctx, userID)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get user badges")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(badges),
+	})
+	h.logger.Info(ctx, "retrieved user badges successfully")
+
+	resp := make([]api.UserBadge, len(badges))
+	for i, b := range badges {
+		resp[i] = mapBadgeToDTO(b)
+	}
+
+	response.OkJSON(w, resp)
+}
+
+// mapBadgeToDTO конвертирует entities.UserBadge в api.UserBadge
+func mapBadgeToDTO(b entities.UserBadge) api.UserBadge {
+	var desc *string
+	if b.Description != "" {
+		desc = &b.Description
+	}
+
+	return api.UserBadge{
+		Code:        b.Code,
+		Title:       b.Title,
+		Description: desc,
+		AwardedAt:   b.AwardedAt,
+	}
+}
@@ -0,0 +1,197 @@
+package users_id_points_history_get
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/cursor"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetPointsHistory(ctx context.Context, userID uuid.UUID, filter entities.LedgerFilter) (*entities.LedgerPage, error)
+}
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"query": r.URL.RawQuery,
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid points history parameters")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"limit":   filter.Limit,
+		"reasons": filter.Reasons,
+	})
+
+	page, err := h.service.GetPointsHistory(ctx, userID, filter)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get points history")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(page.Entries),
+	})
+	h.logger.Info(ctx, "retrieved points history successfully")
+
+	response.OkJSON(w, mapPageToDTO(page))
+}
+
+// parseFilter разбирает параметры запроса; время переводится в локальную зону сервера,
+// в которой хранятся TIMESTAMP-колонки
+func parseFilter(r *http.Request) (entities.LedgerFilter, error) {
+	q := r.URL.Query()
+	filter := entities.LedgerFilter{Limit: defaultLimit}
+
+	if l := q.Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v < 1 || v > maxLimit {
+			return filter, fmt.Errorf("invalid limit parameter %q", l)
+		}
+		filter.Limit = v
+	}
+
+	if c := q.Get("cursor"); c != "" {
+		pos, err := cursor.Decode(c)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &entities.LedgerPosition{CreatedAt: pos.At, ID: pos.ID}
+	}
+
+	for _, value := range q["reason"] {
+		for _, reason := range strings.Split(value, ",") {
+			if reason = strings.TrimSpace(reason); reason != "" {
+				filter.Reasons = append(filter.Reasons, entities.LedgerReason(reason))
+			}
+		}
+	}
+
+	var err error
+	if filter.From, err = parseTime(q.Get("from")); err != nil {
+		return filter, fmt.Errorf("invalid from parameter: %w", err)
+	}
+	if filter.To, err = parseTime(q.Get("to")); err != nil {
+		return filter, fmt.Errorf("invalid to parameter: %w", err)
+	}
+
+	return filter, nil
+}
+
+// parseTime разбирает RFC 3339 и переводит время в локальную зону; пустая строка — nil
+func parseTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	t = t.Local()
+	return &t, nil
+}
+
+// mapPageToDTO конвертирует entities.LedgerPage в api.PointsHistory
+func mapPageToDTO(page *entities.LedgerPage) api.PointsHistory {
+	entries := make([]api.PointsHistoryEntry, len(page.Entries))
+	for i, e := range page.Entries {
+		var taskCode *string
+		if e.TaskCode != "" {
+			taskCode = &e.TaskCode
+		}
+
+		entries[i] = api.PointsHistoryEntry{
+			Id:           e.ID,
+			Amount:       e.Amount,
+			Reason:       string(e.Reason),
+			ReferenceId:  e.ReferenceID,
+			TaskCode:     taskCode,
+			BalanceAfter: e.BalanceAfter,
+			CreatedAt:    e.CreatedAt,
+		}
+	}
+
+	resp := api.PointsHistory{Entries: entries}
+	if page.Next != nil {
+		next := cursor.Encode(cursor.Cursor{At: page.Next.CreatedAt, ID: page.Next.ID})
+		resp.NextCursor = &next
+	}
+
+	return resp
+}
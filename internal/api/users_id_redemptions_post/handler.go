@@ -0,0 +1,130 @@
+package users_id_redemptions_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	RedeemReward(ctx context.Context, userID, rewardID uuid.UUID) (*entities.Redemption, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.RedemptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"reward_id": req.RewardId,
+	})
+
+	if req.RewardId == uuid.Nil {
+		h.logger.Warn(ctx, "empty reward id")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	redemption, err := h.service.RedeemReward(ctx, userID, req.RewardId)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to redeem reward")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"redemption_id": redemption.ID,
+		"price":         redemption.Price,
+	})
+	h.logger.Info(ctx, "reward redeemed successfully")
+
+	response.OkJSON(w, mapRedemptionToDTO(redemption))
+}
+
+// mapRedemptionToDTO конвертирует entities.Redemption в api.Redemption
+func mapRedemptionToDTO(rd *entities.Redemption) api.Redemption {
+	var note *string
+	if rd.Note != "" {
+		note = &rd.Note
+	}
+
+	return api.Redemption{
+		Id:          rd.ID,
+		UserId:      rd.UserID,
+		RewardId:    rd.RewardID,
+		RewardCode:  rd.RewardCode,
+		Price:       rd.Price,
+		Status:      api.RedemptionStatus(rd.Status),
+		ProcessedBy: rd.ProcessedBy,
+		Note:        note,
+		CreatedAt:   rd.CreatedAt,
+		UpdatedAt:   rd.UpdatedAt,
+	}
+}
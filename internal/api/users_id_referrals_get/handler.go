@@ -0,0 +1,163 @@
+package users_id_referrals_get
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/cursor"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetReferralStats(ctx context.Context, userID uuid.UUID, filter entities.RefereeFilter) (*entities.ReferralStats, error)
+}
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"query": r.URL.RawQuery,
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid referrals parameters")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetReferralStats(ctx, userID, filter)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get referral stats")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"total":          stats.Total,
+		"returned_count": len(stats.Referees),
+	})
+	h.logger.Info(ctx, "retrieved referral stats successfully")
+
+	response.OkJSON(w, mapReferralStatsToDTO(stats))
+}
+
+// parseFilter разбирает параметры страницы приглашённых
+func parseFilter(r *http.Request) (entities.RefereeFilter, error) {
+	q := r.URL.Query()
+	filter := entities.RefereeFilter{Limit: defaultLimit}
+
+	if l := q.Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v < 1 || v > maxLimit {
+			return filter, fmt.Errorf("invalid limit parameter %q", l)
+		}
+		filter.Limit = v
+	}
+
+	if c := q.Get("cursor"); c != "" {
+		pos, err := cursor.Decode(c)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &entities.RefereePosition{ReferredAt: pos.At, ID: pos.ID}
+	}
+
+	return filter, nil
+}
+
+// mapReferralStatsToDTO конвертирует entities.ReferralStats в api.ReferralStats
+func mapReferralStatsToDTO(stats *entities.ReferralStats) api.ReferralStats {
+	referees := make([]api.Referee, len(stats.Referees))
+	for i, r := range stats.Referees {
+		referees[i] = api.Referee{
+			UserId:         r.UserID,
+			Username:       r.Username,
+			ReferredAt:     r.ReferredAt,
+			CompletedTasks: r.CompletedTasks,
+			Active:         r.IsActive(),
+			Earned:         r.Earned,
+		}
+	}
+
+	resp := api.ReferralStats{
+		UserId:          stats.UserID,
+		Total:           stats.Total,
+		Active:          stats.Active,
+		JoinedLastDay:   stats.LastDay,
+		JoinedLastWeek:  stats.LastWeek,
+		JoinedLastMonth: stats.LastMonth,
+		TotalEarned:     stats.TotalEarned,
+		Referees:        referees,
+	}
+	if stats.Next != nil {
+		next := cursor.Encode(cursor.Cursor{At: stats.Next.ReferredAt, ID: stats.Next.ID})
+		resp.NextCursor = &next
+	}
+
+	return resp
+}
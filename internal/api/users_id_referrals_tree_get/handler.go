@@ -0,0 +1,129 @@
+package users_id_referrals_tree_get
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxDepth — максимальная глубина сети, которую можно запросить
+const maxDepth = 10
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetReferralTree(ctx context.Context, userID uuid.UUID, depth int) (*entities.ReferralTree, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	// глубина необязательна, по умолчанию её выбирает сервис
+	depth := 0
+	if d := r.URL.Query().Get("depth"); d != "" {
+		v, err := strconv.Atoi(d)
+		if err != nil || v < 1 || v > maxDepth {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"depth_param": d,
+			})
+			h.logger.Warn(ctx, "invalid depth parameter")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+		depth = v
+	}
+
+	tree, err := h.service.GetReferralTree(ctx, userID, depth)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get referral tree")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"depth": tree.Depth,
+		"nodes": len(tree.Nodes),
+	})
+	h.logger.Info(ctx, "referral tree fetched successfully")
+
+	response.OkJSON(w, mapReferralTreeToDTO(tree))
+}
+
+// mapReferralTreeToDTO конвертирует entities.ReferralTree в api.ReferralTree
+func mapReferralTreeToDTO(t *entities.ReferralTree) api.ReferralTree {
+	nodes := make([]api.ReferralTreeNode, len(t.Nodes))
+	for i, n := range t.Nodes {
+		nodes[i] = api.ReferralTreeNode{
+			UserId:     n.UserID,
+			Username:   n.Username,
+			ReferrerId: n.ReferrerID,
+			Level:      n.Level,
+			Earned:     n.Earned,
+			JoinedAt:   n.JoinedAt,
+		}
+	}
+
+	return api.ReferralTree{
+		UserId:      t.UserID,
+		Depth:       t.Depth,
+		TotalEarned: t.TotalEarned,
+		Nodes:       nodes,
+	}
+}
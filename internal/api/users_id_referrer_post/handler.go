@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"service-boilerplate-go/internal/generated/api"
 	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
@@ -23,6 +24,7 @@ type Logger interface {
 
 type Service interface {
 	InputReferrer(ctx context.Context, userID, referrerID uuid.UUID) error
+	InputReferrerByCode(ctx context.Context, userID uuid.UUID, code string) error
 }
 
 type Handler struct {
@@ -76,17 +78,31 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.ReferrerId == uuid.Nil {
-		h.logger.Warn(ctx, "empty referrer id")
+	var code string
+	if req.ReferralCode != nil {
+		code = strings.TrimSpace(*req.ReferralCode)
+	}
+	hasID := req.ReferrerId != nil && *req.ReferrerId != uuid.Nil
+
+	// реферер указывается либо по id, либо по коду приглашения
+	if hasID == (code != "") {
+		h.logger.Warn(ctx, "exactly one of referrer id and referral code is required")
 		response.ErrorStatus(w, http.StatusBadRequest)
 		return
 	}
 
-	ctx = h.logger.WithFields(ctx, map[string]any{
-		"referrer_id": req.ReferrerId,
-	})
-
-	if err := h.service.InputReferrer(ctx, userID, req.ReferrerId); err != nil {
+	if hasID {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"referrer_id": *req.ReferrerId,
+		})
+		err = h.service.InputReferrer(ctx, userID, *req.ReferrerId)
+	} else {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"referral_code": code,
+		})
+		err = h.service.InputReferrerByCode(ctx, userID, code)
+	}
+	if err != nil {
 		ctx = h.logger.WithFields(ctx, map[string]any{
 			"error": err.Error(),
 		})
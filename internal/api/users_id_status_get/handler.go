@@ -7,6 +7,7 @@ import (
 	"service-boilerplate-go/internal/service/entities"
 
 	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/locale"
 	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
 	"service-boilerplate-go/internal/pkg/response"
 
@@ -23,7 +24,7 @@ type Logger interface {
 }
 
 type Service interface {
-	GetUserStatus(ctx context.Context, userID uuid.UUID) (*entities.UserStatus, error)
+	GetUserStatus(ctx context.Context, userID uuid.UUID, locales []string) (*entities.UserStatus, error)
 }
 
 type Handler struct {
@@ -69,8 +70,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// вызываем сервис
-	statusEntity, err := h.service.GetUserStatus(ctx, userID)
+	// вызываем сервис, тексты заданий подбираются по Accept-Language
+	statusEntity, err := h.service.GetUserStatus(ctx, userID, locale.FromRequest(r))
 	if err != nil {
 		ctx = h.logger.WithFields(ctx, map[string]any{
 			"error": err.Error(),
@@ -106,21 +107,78 @@ func mapUserStatusToDTO(us *entities.UserStatus) *api.UserStatus {
 			meta = &t.Metadata
 		}
 
+		var boost *api.PointsBoost
+		if t.Boost != nil {
+			boost = &api.PointsBoost{EventId: t.Boost.EventID}
+			if t.Boost.EventName != "" {
+				boost.EventName = &t.Boost.EventName
+			}
+		}
+
 		completed[i] = api.CompletedTask{
 			Id:          t.ID,
 			Code:        t.Code,
+			Title:       &t.Title,
 			Description: desc,
 			Points:      t.Points,
+			BasePoints:  t.BasePoints,
+			Multiplier:  t.Multiplier,
+			Boost:       boost,
 			Metadata:    meta,
 			CompletedAt: t.CompletedAt,
 		}
 	}
 
+	inProgress := make([]api.TaskProgress, len(us.TasksInProgress))
+	for i, p := range us.TasksInProgress {
+		var desc *string
+		if p.Description != "" {
+			desc = &p.Description
+		}
+
+		inProgress[i] = api.TaskProgress{
+			TaskId:      p.TaskID,
+			Code:        p.Code,
+			Title:       &p.Title,
+			Description: desc,
+			Progress:    p.Progress,
+			Target:      p.Target,
+			Completed:   p.Completed,
+		}
+	}
+
+	badges := make([]api.UserBadge, len(us.Badges))
+	for i, b := range us.Badges {
+		var desc *string
+		if b.Description != "" {
+			desc = &b.Description
+		}
+
+		badges[i] = api.UserBadge{
+			Code:        b.Code,
+			Title:       b.Title,
+			Description: desc,
+			AwardedAt:   b.AwardedAt,
+		}
+	}
+
+	expiring := make([]api.ExpiringPoints, len(us.ExpiringSoon))
+	for i, e := range us.ExpiringSoon {
+		expiring[i] = api.ExpiringPoints{
+			Points:    e.Points,
+			ExpiresAt: e.ExpiresAt,
+		}
+	}
+
 	return &api.UserStatus{
-		Id:             us.ID,
-		Username:       us.Username,
-		Points:         us.Points,
-		ReferrerId:     us.ReferrerID,
-		CompletedTasks: completed,
+		Id:              us.ID,
+		Username:        us.Username,
+		Points:          us.Points,
+		ReferrerId:      us.ReferrerID,
+		ReferralCode:    us.ReferralCode,
+		CompletedTasks:  completed,
+		TasksInProgress: inProgress,
+		Badges:          badges,
+		ExpiringSoon:    expiring,
 	}
 }
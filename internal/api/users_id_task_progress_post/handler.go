@@ -0,0 +1,139 @@
+package users_id_task_progress_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	IncrementTaskProgress(ctx context.Context, userID, taskID uuid.UUID, increment int) (*entities.TaskProgress, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.TaskProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.TaskId == uuid.Nil {
+		h.logger.Warn(ctx, "empty task id")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	increment := 1
+	if req.Increment != nil {
+		increment = *req.Increment
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"task_id":   req.TaskId,
+		"increment": increment,
+	})
+
+	if increment < 1 {
+		h.logger.Warn(ctx, "non-positive increment")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	progress, err := h.service.IncrementTaskProgress(ctx, userID, req.TaskId, increment)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to increment task progress")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"progress":  progress.Progress,
+		"completed": progress.Completed,
+	})
+	h.logger.Info(ctx, "task progress incremented successfully")
+
+	response.OkJSON(w, mapTaskProgressToDTO(progress))
+}
+
+// mapTaskProgressToDTO конвертирует entities.TaskProgress в api.TaskProgress
+func mapTaskProgressToDTO(p *entities.TaskProgress) api.TaskProgress {
+	var desc *string
+	if p.Description != "" {
+		desc = &p.Description
+	}
+
+	return api.TaskProgress{
+		TaskId:      p.TaskID,
+		Code:        p.Code,
+		Title:       &p.Title,
+		Description: desc,
+		Progress:    p.Progress,
+		Target:      p.Target,
+		Completed:   p.Completed,
+	}
+}
@@ -0,0 +1,151 @@
+package users_id_transfers_post
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"unicode/utf8"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxNoteLength — максимальная длина комментария к переводу
+const maxNoteLength = 255
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	TransferPoints(ctx context.Context, transfer entities.PointsTransfer) (*entities.PointsTransfer, error)
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// получаем userID из JWT
+	userIDStrCtx, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	// получаем userID из пути
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id_path":  userIDStr,
+		"user_id_token": userIDStrCtx,
+	})
+
+	if userIDStrCtx != userIDStr {
+		h.logger.Warn(ctx, "unauthorized: token user id does not match path user id")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var req api.TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"recipient_id": req.RecipientId,
+		"amount":       req.Amount,
+	})
+
+	if req.RecipientId == uuid.Nil {
+		h.logger.Warn(ctx, "empty recipient id")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.Amount <= 0 {
+		h.logger.Warn(ctx, "amount must be positive")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	var note string
+	if req.Note != nil {
+		note = *req.Note
+	}
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		h.logger.Warn(ctx, "note is too long")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.service.TransferPoints(ctx, entities.PointsTransfer{
+		SenderID:    userID,
+		RecipientID: req.RecipientId,
+		Amount:      req.Amount,
+		Note:        note,
+	})
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to transfer points")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"transfer_id": transfer.ID,
+	})
+	h.logger.Info(ctx, "points transferred successfully")
+
+	response.OkJSON(w, mapTransferToDTO(transfer))
+}
+
+// mapTransferToDTO конвертирует entities.PointsTransfer в api.PointsTransfer
+func mapTransferToDTO(t *entities.PointsTransfer) api.PointsTransfer {
+	var note *string
+	if t.Note != "" {
+		note = &t.Note
+	}
+
+	return api.PointsTransfer{
+		Id:          t.ID,
+		SenderId:    t.SenderID,
+		RecipientId: t.RecipientID,
+		Amount:      t.Amount,
+		Note:        note,
+		CreatedAt:   t.CreatedAt,
+	}
+}
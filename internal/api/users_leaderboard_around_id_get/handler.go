@@ -0,0 +1,113 @@
+package users_leaderboard_around_id_get
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	GetLeaderboardAround(ctx context.Context, userID uuid.UUID, radius int) (*entities.LeaderboardAround, error)
+}
+
+const (
+	defaultRadius = 5
+	maxRadius     = 50
+)
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// рейтинг общий, поэтому соседей можно смотреть для любого пользователя
+	userIDStr := mux.Vars(r)["id"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id": userIDStr,
+	})
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	radius := defaultRadius
+	if rs := r.URL.Query().Get("radius"); rs != "" {
+		v, err := strconv.Atoi(rs)
+		if err != nil || v < 1 || v > maxRadius {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"radius_param": rs,
+			})
+			h.logger.Warn(ctx, "invalid radius parameter")
+			response.ErrorStatus(w, http.StatusBadRequest)
+			return
+		}
+		radius = v
+	}
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"radius": radius,
+	})
+
+	around, err := h.service.GetLeaderboardAround(ctx, userID, radius)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to get leaderboard around user")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"returned_count": len(around.Users),
+	})
+	h.logger.Info(ctx, "leaderboard around user retrieved successfully")
+
+	response.OkJSON(w, mapAroundToDTO(around))
+}
+
+// mapAroundToDTO конвертирует entities.LeaderboardAround в api.LeaderboardAround
+func mapAroundToDTO(around *entities.LeaderboardAround) api.LeaderboardAround {
+	users := make([]api.LeaderboardUser, len(around.Users))
+	for i, u := range around.Users {
+		users[i] = api.LeaderboardUser{
+			Id:       u.ID,
+			Username: u.Username,
+			Points:   u.Points,
+			Rank:     u.Rank,
+		}
+	}
+
+	return api.LeaderboardAround{
+		UserId: around.UserID,
+		Radius: around.Radius,
+		Users:  users,
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"strconv"
 
 	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
 	"service-boilerplate-go/internal/pkg/response"
 	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
 )
 
 type Logger interface {
@@ -19,7 +22,7 @@ type Logger interface {
 }
 
 type Service interface {
-	GetUsersLeaderboard(ctx context.Context, limit, offset int) (entities.UsersLeaderboard, error)
+	GetUsersLeaderboard(ctx context.Context, userID uuid.UUID, period entities.LeaderboardPeriod, limit, offset int) (*entities.LeaderboardPage, error)
 }
 
 type Handler struct {
@@ -34,6 +37,42 @@ func New(logger Logger, service Service) *Handler {
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// получаем userID из JWT, для него возвращается собственное место
+	userIDStr, ok := jwtauth.UserIDFromContext(ctx)
+	if !ok {
+		h.logger.Warn(ctx, "unauthorized: no user id in context")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"user_id": userIDStr,
+	})
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "invalid user id format")
+		response.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	period := entities.LeaderboardPeriodAll
+	if p := r.URL.Query().Get("period"); p != "" {
+		period = entities.LeaderboardPeriod(p)
+	}
+	switch period {
+	case entities.LeaderboardPeriodAll, entities.LeaderboardPeriodDay, entities.LeaderboardPeriodWeek, entities.LeaderboardPeriodMonth:
+	default:
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"period_param": period,
+		})
+		h.logger.Warn(ctx, "invalid period parameter")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
 	limit, offset := 10, 0
 	if l := r.URL.Query().Get("limit"); l != "" {
 		if v, err := strconv.Atoi(l); err == nil && v > 0 && v < 100 {
@@ -47,7 +86,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if o := r.URL.Query().Get("offset"); o != "" {
+	o, p := r.URL.Query().Get("offset"), r.URL.Query().Get("page")
+	if o != "" && p != "" {
+		h.logger.Warn(ctx, "offset and page parameters are mutually exclusive")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	if o != "" {
 		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
 			offset = v
 		} else {
@@ -59,12 +105,26 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// page нумеруется с 1 и задаёт смещение в страницах размера limit
+	if p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v > 0 {
+			offset = (v - 1) * limit
+		} else {
+			ctx = h.logger.WithFields(ctx, map[string]any{
+				"page_param": p,
+				"error":      err,
+			})
+			h.logger.Warn(ctx, "invalid page parameter, using default 1")
+		}
+	}
+
 	ctx = h.logger.WithFields(ctx, map[string]any{
+		"period": period,
 		"limit":  limit,
 		"offset": offset,
 	})
 
-	leaders, err := h.service.GetUsersLeaderboard(ctx, limit, offset)
+	page, err := h.service.GetUsersLeaderboard(ctx, userID, period, limit, offset)
 	if err != nil {
 		ctx = h.logger.WithFields(ctx, map[string]any{
 			"error": err.Error(),
@@ -74,19 +134,42 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := make([]api.LeaderboardUser, len(leaders))
-	for i, u := range leaders {
-		resp[i] = api.LeaderboardUser{
-			Id:       u.ID,
-			Username: u.Username,
-			Points:   u.Points,
-		}
-	}
-
 	ctx = h.logger.WithFields(ctx, map[string]any{
-		"returned_count": len(resp),
+		"returned_count": len(page.Users),
+		"total":          page.Total,
 	})
 	h.logger.Info(ctx, "users leaderboard retrieved successfully")
 
-	response.OkJSON(w, resp)
+	response.OkJSON(w, mapPageToDTO(page, limit, offset))
+}
+
+// mapPageToDTO конвертирует entities.LeaderboardPage в api.Leaderboard
+func mapPageToDTO(page *entities.LeaderboardPage, limit, offset int) api.Leaderboard {
+	users := make([]api.LeaderboardUser, len(page.Users))
+	for i, u := range page.Users {
+		users[i] = mapLeaderToDTO(u)
+	}
+
+	resp := api.Leaderboard{
+		Period: api.LeaderboardPeriod(page.Period),
+		Total:  page.Total,
+		Limit:  limit,
+		Offset: offset,
+		Users:  users,
+	}
+	if page.Me != nil {
+		me := mapLeaderToDTO(*page.Me)
+		resp.Me = &me
+	}
+
+	return resp
+}
+
+func mapLeaderToDTO(u entities.UserLeader) api.LeaderboardUser {
+	return api.LeaderboardUser{
+		Id:       u.ID,
+		Username: u.Username,
+		Points:   u.Points,
+		Rank:     u.Rank,
+	}
 }
@@ -0,0 +1,115 @@
+package webhooks_partner_task_completions_post
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"service-boilerplate-go/internal/generated/api"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/gorilla/mux"
+)
+
+// maxBodySize ограничивает размер тела доставки
+const maxBodySize = 1 << 20
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+	Info(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	VerifyWebhookSignature(partner, timestamp, signature string, body []byte) error
+	CompletePartnerTask(ctx context.Context, completion entities.PartnerTaskCompletion) error
+}
+
+type Handler struct {
+	logger  Logger
+	service Service
+}
+
+func New(logger Logger, service Service) *Handler {
+	return &Handler{logger: logger, service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	partner := mux.Vars(r)["partner"]
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"partner": partner,
+	})
+
+	// подпись считается по сырому телу, поэтому читаем его целиком
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to read webhook body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get("X-Webhook-Timestamp")
+	signature := r.Header.Get("X-Webhook-Signature")
+
+	if err := h.service.VerifyWebhookSignature(partner, timestamp, signature, body); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error":     err.Error(),
+			"timestamp": timestamp,
+		})
+		h.logger.Warn(ctx, "webhook signature verification failed")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	var req api.PartnerTaskCompletionRequest
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&req); err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Warn(ctx, "failed to decode json body")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	ctx = h.logger.WithFields(ctx, map[string]any{
+		"nonce":            req.Nonce,
+		"external_user_id": req.ExternalUserId,
+		"task_code":        req.TaskCode,
+	})
+
+	if strings.TrimSpace(req.Nonce) == "" || req.ExternalUserId == "" || req.TaskCode == "" {
+		h.logger.Warn(ctx, "missing required webhook fields")
+		response.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.CompletePartnerTask(ctx, entities.PartnerTaskCompletion{
+		Partner:        partner,
+		ExternalUserID: req.ExternalUserId,
+		TaskCode:       req.TaskCode,
+		Nonce:          req.Nonce,
+		Payload:        body,
+	})
+	if err != nil {
+		ctx = h.logger.WithFields(ctx, map[string]any{
+			"error": err.Error(),
+		})
+		h.logger.Error(ctx, "failed to complete partner task")
+		response.ErrorDomain(w, err)
+		return
+	}
+
+	h.logger.Info(ctx, "partner task completed successfully")
+	response.OkJSON(w, api.TaskCompleteResponse{Status: "ok"})
+}
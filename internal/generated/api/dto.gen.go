@@ -13,10 +13,97 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for AdjustmentReason.
+const (
+	Compensation AdjustmentReason = "compensation"
+	Correction   AdjustmentReason = "correction"
+	Fraud        AdjustmentReason = "fraud"
+	Goodwill     AdjustmentReason = "goodwill"
+)
+
+// Defines values for LeaderboardPeriod.
+const (
+	All   LeaderboardPeriod = "all"
+	Day   LeaderboardPeriod = "day"
+	Month LeaderboardPeriod = "month"
+	Week  LeaderboardPeriod = "week"
+)
+
+// Defines values for ReconciliationMismatchIssue.
+const (
+	BalanceDrift           ReconciliationMismatchIssue = "balance_drift"
+	LedgerDrift            ReconciliationMismatchIssue = "ledger_drift"
+	MissingCompletionEntry ReconciliationMismatchIssue = "missing_completion_entry"
+	MissingRevocationEntry ReconciliationMismatchIssue = "missing_revocation_entry"
+)
+
+// Defines values for RedemptionStatus.
+const (
+	RedemptionStatusCancelled RedemptionStatus = "cancelled"
+	RedemptionStatusFulfilled RedemptionStatus = "fulfilled"
+	RedemptionStatusPending   RedemptionStatus = "pending"
+)
+
+// Defines values for ReferralReviewStatus.
+const (
+	ReferralReviewStatusApproved ReferralReviewStatus = "approved"
+	ReferralReviewStatusPending  ReferralReviewStatus = "pending"
+	ReferralReviewStatusRejected ReferralReviewStatus = "rejected"
+)
+
+// Defines values for ReferralReviewTrigger.
+const (
+	ReferrerSet   ReferralReviewTrigger = "referrer_set"
+	TaskCompleted ReferralReviewTrigger = "task_completed"
+)
+
+// Defines values for TaskImportRowResultStatus.
+const (
+	Completed TaskImportRowResultStatus = "completed"
+	Failed    TaskImportRowResultStatus = "failed"
+	Skipped   TaskImportRowResultStatus = "skipped"
+	Valid     TaskImportRowResultStatus = "valid"
+)
+
+// Defines values for GetAdminRedemptionsParamsStatus.
+const (
+	GetAdminRedemptionsParamsStatusCancelled GetAdminRedemptionsParamsStatus = "cancelled"
+	GetAdminRedemptionsParamsStatusFulfilled GetAdminRedemptionsParamsStatus = "fulfilled"
+	GetAdminRedemptionsParamsStatusPending   GetAdminRedemptionsParamsStatus = "pending"
+)
+
+// Defines values for GetAdminReferralReviewsParamsStatus.
+const (
+	GetAdminReferralReviewsParamsStatusApproved GetAdminReferralReviewsParamsStatus = "approved"
+	GetAdminReferralReviewsParamsStatusPending  GetAdminReferralReviewsParamsStatus = "pending"
+	GetAdminReferralReviewsParamsStatusRejected GetAdminReferralReviewsParamsStatus = "rejected"
+)
+
+// Defines values for PostAdminTaskCompletionsImportParamsFormat.
+const (
+	Csv   PostAdminTaskCompletionsImportParamsFormat = "csv"
+	Jsonl PostAdminTaskCompletionsImportParamsFormat = "jsonl"
+)
+
+// AdjustmentReason defines model for AdjustmentReason.
+type AdjustmentReason string
+
 // AuthRequest defines model for AuthRequest.
 type AuthRequest struct {
 	Password string `json:"password"`
-	Username string `json:"username"`
+
+	// ReferralCode Код приглашения; применяется только при регистрации
+	ReferralCode *string `json:"referral_code,omitempty"`
+
+	// ReferrerId ID реферера вместо кода приглашения; применяется только при регистрации
+	ReferrerId *openapi_types.UUID `json:"referrer_id,omitempty"`
+	Username   string              `json:"username"`
+
+	// UtmCampaign Кампания для маркетинговых отчётов
+	UtmCampaign *string `json:"utm_campaign,omitempty"`
+
+	// UtmSource Источник перехода для маркетинговых отчётов
+	UtmSource *string `json:"utm_source,omitempty"`
 }
 
 // AuthResponse defines model for AuthResponse.
@@ -25,14 +112,39 @@ type AuthResponse struct {
 	UserId openapi_types.UUID `json:"user_id"`
 }
 
+// CatalogTask defines model for CatalogTask.
+type CatalogTask struct {
+	Category *string `json:"category,omitempty"`
+	Code     string  `json:"code"`
+
+	// CompletionCap Глобальный лимит выполнений, отсутствует для задач без лимита
+	CompletionCap *int               `json:"completion_cap,omitempty"`
+	Description   *string            `json:"description,omitempty"`
+	Id            openapi_types.UUID `json:"id"`
+
+	// RemainingSlots Сколько выполнений осталось до лимита
+	RemainingSlots *int     `json:"remaining_slots,omitempty"`
+	RewardPoints   int      `json:"reward_points"`
+	Tags           []string `json:"tags"`
+	TargetCount    int      `json:"target_count"`
+	Title          string   `json:"title"`
+}
+
 // CompletedTask defines model for CompletedTask.
 type CompletedTask struct {
+	// BasePoints Награда задания без множителя
+	BasePoints  int                `json:"base_points"`
+	Boost       *PointsBoost       `json:"boost,omitempty"`
 	Code        string             `json:"code"`
 	CompletedAt time.Time          `json:"completed_at"`
 	Description *string            `json:"description,omitempty"`
 	Id          openapi_types.UUID `json:"id"`
 	Metadata    *map[string]string `json:"metadata,omitempty"`
-	Points      int                `json:"points"`
+	Multiplier  float64            `json:"multiplier"`
+
+	// Points Начисленные баллы с учётом множителя
+	Points int     `json:"points"`
+	Title  *string `json:"title,omitempty"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -40,16 +152,340 @@ type ErrorResponse struct {
 	Errors string `json:"errors"`
 }
 
+// ExpiringPoints defines model for ExpiringPoints.
+type ExpiringPoints struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Points    int       `json:"points"`
+}
+
+// Leaderboard defines model for Leaderboard.
+type Leaderboard struct {
+	Limit  int              `json:"limit"`
+	Me     *LeaderboardUser `json:"me,omitempty"`
+	Offset int              `json:"offset"`
+
+	// Period all — по балансу; day, week, month — по баллам, заработанным с начала текущих суток, недели (с понедельника) или месяца
+	Period LeaderboardPeriod `json:"period"`
+
+	// Total Число участников рейтинга; в рейтинге за всё время обновляется раз в 30 секунд
+	Total int               `json:"total"`
+	Users []LeaderboardUser `json:"users"`
+}
+
+// LeaderboardAround defines model for LeaderboardAround.
+type LeaderboardAround struct {
+	Radius int                `json:"radius"`
+	UserId openapi_types.UUID `json:"user_id"`
+
+	// Users Стоящие выше, сам пользователь и стоящие ниже, в порядке рейтинга
+	Users []LeaderboardUser `json:"users"`
+}
+
+// LeaderboardPeriod all — по балансу; day, week, month — по баллам, заработанным с начала текущих суток, недели (с понедельника) или месяца
+type LeaderboardPeriod string
+
 // LeaderboardUser defines model for LeaderboardUser.
 type LeaderboardUser struct {
-	Id       openapi_types.UUID `json:"id"`
-	Points   int                `json:"points"`
-	Username string             `json:"username"`
+	Id     openapi_types.UUID `json:"id"`
+	Points int                `json:"points"`
+
+	// Rank Место; пользователи с равными баллами делят место (1, 2, 2, 4), порядок внутри места — по id
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
 }
 
-// ReferrerRequest defines model for ReferrerRequest.
-type ReferrerRequest struct {
+// MultiplierEvent defines model for MultiplierEvent.
+type MultiplierEvent struct {
+	Categories []string             `json:"categories"`
+	CreatedAt  time.Time            `json:"created_at"`
+	EndsAt     time.Time            `json:"ends_at"`
+	Id         openapi_types.UUID   `json:"id"`
+	Multiplier float64              `json:"multiplier"`
+	Name       string               `json:"name"`
+	StartsAt   time.Time            `json:"starts_at"`
+	TaskIds    []openapi_types.UUID `json:"task_ids"`
+}
+
+// MultiplierEventRequest defines model for MultiplierEventRequest.
+type MultiplierEventRequest struct {
+	// Categories Коды категорий, на которые действует событие
+	Categories *[]string `json:"categories,omitempty"`
+	EndsAt     time.Time `json:"ends_at"`
+
+	// Multiplier Множитель награды, не больше 9999.99 и не точнее сотых
+	Multiplier float64   `json:"multiplier"`
+	Name       string    `json:"name"`
+	StartsAt   time.Time `json:"starts_at"`
+
+	// TaskIds Задания, на которые действует событие
+	TaskIds *[]openapi_types.UUID `json:"task_ids,omitempty"`
+}
+
+// PartnerTaskCompletionRequest defines model for PartnerTaskCompletionRequest.
+type PartnerTaskCompletionRequest struct {
+	// ExternalUserId Идентификатор пользователя в системе партнёра
+	ExternalUserId string `json:"external_user_id"`
+
+	// Nonce Уникальный идентификатор доставки, повтор отклоняется
+	Nonce    string `json:"nonce"`
+	TaskCode string `json:"task_code"`
+}
+
+// PartnerUserLink defines model for PartnerUserLink.
+type PartnerUserLink struct {
+	CreatedAt      time.Time          `json:"created_at"`
+	ExternalUserId string             `json:"external_user_id"`
+	Partner        string             `json:"partner"`
+	UserId         openapi_types.UUID `json:"user_id"`
+}
+
+// PartnerUserLinkRequest defines model for PartnerUserLinkRequest.
+type PartnerUserLinkRequest struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// PointsAdjustment defines model for PointsAdjustment.
+type PointsAdjustment struct {
+	AdminId    openapi_types.UUID `json:"admin_id"`
+	Amount     int                `json:"amount"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Id         openapi_types.UUID `json:"id"`
+	Note       string             `json:"note"`
+	ReasonCode AdjustmentReason   `json:"reason_code"`
+	UserId     openapi_types.UUID `json:"user_id"`
+}
+
+// PointsAdjustmentBatch defines model for PointsAdjustmentBatch.
+type PointsAdjustmentBatch struct {
+	Adjustments    []PointsAdjustment `json:"adjustments"`
+	IdempotencyKey string             `json:"idempotency_key"`
+
+	// Replayed Результат сохранён предыдущим запросом с тем же ключом
+	Replayed bool `json:"replayed"`
+}
+
+// PointsAdjustmentItem defines model for PointsAdjustmentItem.
+type PointsAdjustmentItem struct {
+	// Amount Изменение баланса, не ноль; отрицательное — списание
+	Amount int `json:"amount"`
+
+	// Note Комментарий для аудита
+	Note       string             `json:"note"`
+	ReasonCode AdjustmentReason   `json:"reason_code"`
+	UserId     openapi_types.UUID `json:"user_id"`
+}
+
+// PointsAdjustmentRequest defines model for PointsAdjustmentRequest.
+type PointsAdjustmentRequest struct {
+	Adjustments []PointsAdjustmentItem `json:"adjustments"`
+}
+
+// PointsBoost defines model for PointsBoost.
+type PointsBoost struct {
+	EventId   *openapi_types.UUID `json:"event_id"`
+	EventName *string             `json:"event_name,omitempty"`
+}
+
+// PointsHistory defines model for PointsHistory.
+type PointsHistory struct {
+	Entries []PointsHistoryEntry `json:"entries"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// PointsHistoryEntry defines model for PointsHistoryEntry.
+type PointsHistoryEntry struct {
+	// Amount Изменение баланса, отрицательное для списаний
+	Amount int `json:"amount"`
+
+	// BalanceAfter Баланс после изменения
+	BalanceAfter int                 `json:"balance_after"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Id           openapi_types.UUID  `json:"id"`
+	Reason       string              `json:"reason"`
+	ReferenceId  *openapi_types.UUID `json:"reference_id,omitempty"`
+
+	// TaskCode Код задачи для записей по выполнениям
+	TaskCode *string `json:"task_code,omitempty"`
+}
+
+// PointsTransfer defines model for PointsTransfer.
+type PointsTransfer struct {
+	Amount      int                `json:"amount"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Id          openapi_types.UUID `json:"id"`
+	Note        *string            `json:"note,omitempty"`
+	RecipientId openapi_types.UUID `json:"recipient_id"`
+	SenderId    openapi_types.UUID `json:"sender_id"`
+}
+
+// ReconciliationMismatch defines model for ReconciliationMismatch.
+type ReconciliationMismatch struct {
+	Actual int `json:"actual"`
+
+	// Expected Ожидаемый баланс или сумма недостающей проводки
+	Expected int                         `json:"expected"`
+	Fixed    bool                        `json:"fixed"`
+	Issue    ReconciliationMismatchIssue `json:"issue"`
+
+	// ReferenceId Выполнение задачи для недостающих проводок
+	ReferenceId *openapi_types.UUID `json:"reference_id,omitempty"`
+	UserId      openapi_types.UUID  `json:"user_id"`
+}
+
+// ReconciliationMismatchIssue defines model for ReconciliationMismatch.Issue.
+type ReconciliationMismatchIssue string
+
+// ReconciliationReport defines model for ReconciliationReport.
+type ReconciliationReport struct {
+	Fix        bool                     `json:"fix"`
+	FixedUsers int                      `json:"fixed_users"`
+	Mismatches []ReconciliationMismatch `json:"mismatches"`
+}
+
+// Redemption defines model for Redemption.
+type Redemption struct {
+	CreatedAt   time.Time           `json:"created_at"`
+	Id          openapi_types.UUID  `json:"id"`
+	Note        *string             `json:"note,omitempty"`
+	Price       int                 `json:"price"`
+	ProcessedBy *openapi_types.UUID `json:"processed_by,omitempty"`
+	RewardCode  string              `json:"reward_code"`
+	RewardId    openapi_types.UUID  `json:"reward_id"`
+	Status      RedemptionStatus    `json:"status"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	UserId      openapi_types.UUID  `json:"user_id"`
+}
+
+// RedemptionStatus defines model for Redemption.Status.
+type RedemptionStatus string
+
+// RedemptionDecisionRequest defines model for RedemptionDecisionRequest.
+type RedemptionDecisionRequest struct {
+	// Note Комментарий администратора (например, трек-номер)
+	Note *string `json:"note,omitempty"`
+}
+
+// RedemptionRequest defines model for RedemptionRequest.
+type RedemptionRequest struct {
+	RewardId openapi_types.UUID `json:"reward_id"`
+}
+
+// Referee defines model for Referee.
+type Referee struct {
+	// Active Выполнил хотя бы одно задание
+	Active         bool `json:"active"`
+	CompletedTasks int  `json:"completed_tasks"`
+
+	// Earned Бонус и комиссии, полученные за приглашённого
+	Earned     int                `json:"earned"`
+	ReferredAt time.Time          `json:"referred_at"`
+	UserId     openapi_types.UUID `json:"user_id"`
+	Username   string             `json:"username"`
+}
+
+// ReferralCodeRequest defines model for ReferralCodeRequest.
+type ReferralCodeRequest struct {
+	// Code Латинские буквы, цифры и дефис не по краям; регистр не важен
+	Code string `json:"code"`
+}
+
+// ReferralCodeResponse defines model for ReferralCodeResponse.
+type ReferralCodeResponse struct {
+	ReferralCode string `json:"referral_code"`
+}
+
+// ReferralProfile defines model for ReferralProfile.
+type ReferralProfile struct {
+	ReferralCode string             `json:"referral_code"`
+	UserId       openapi_types.UUID `json:"user_id"`
+	Username     string             `json:"username"`
+}
+
+// ReferralReview defines model for ReferralReview.
+type ReferralReview struct {
+	CreatedAt  time.Time           `json:"created_at"`
+	Id         openapi_types.UUID  `json:"id"`
+	Note       *string             `json:"note,omitempty"`
+	RefereeId  openapi_types.UUID  `json:"referee_id"`
+	ReferrerId openapi_types.UUID  `json:"referrer_id"`
+	ReviewedAt *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewedBy *openapi_types.UUID `json:"reviewed_by,omitempty"`
+	Score      int                 `json:"score"`
+
+	// Signals Сработавшие признаки (shared_ip, shared_user_agent, signup_burst, idle_referees)
+	Signals []string             `json:"signals"`
+	Status  ReferralReviewStatus `json:"status"`
+
+	// Trigger Момент проверки
+	Trigger ReferralReviewTrigger `json:"trigger"`
+}
+
+// ReferralReviewStatus defines model for ReferralReview.Status.
+type ReferralReviewStatus string
+
+// ReferralReviewTrigger Момент проверки
+type ReferralReviewTrigger string
+
+// ReferralReviewDecisionRequest defines model for ReferralReviewDecisionRequest.
+type ReferralReviewDecisionRequest struct {
+	// Note Комментарий администратора
+	Note *string `json:"note,omitempty"`
+}
+
+// ReferralStats defines model for ReferralStats.
+type ReferralStats struct {
+	// Active Приглашённые, выполнившие хотя бы одно задание
+	Active        int `json:"active"`
+	JoinedLastDay int `json:"joined_last_day"`
+
+	// JoinedLastMonth Приглашены за последние 30 дней
+	JoinedLastMonth int `json:"joined_last_month"`
+	JoinedLastWeek  int `json:"joined_last_week"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Referees Прямые приглашённые, новые первыми
+	Referees []Referee `json:"referees"`
+
+	// Total Все прямые приглашённые
+	Total int `json:"total"`
+
+	// TotalEarned Бонусы за приглашения и комиссии со всей сети
+	TotalEarned int                `json:"total_earned"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+// ReferralTree defines model for ReferralTree.
+type ReferralTree struct {
+	Depth int `json:"depth"`
+
+	// Nodes Участники сети в порядке обхода в ширину
+	Nodes       []ReferralTreeNode `json:"nodes"`
+	TotalEarned int                `json:"total_earned"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+// ReferralTreeNode defines model for ReferralTreeNode.
+type ReferralTreeNode struct {
+	// Earned Комиссии, полученные с начислений участника
+	Earned   int       `json:"earned"`
+	JoinedAt time.Time `json:"joined_at"`
+
+	// Level 1 — приглашён напрямую
+	Level      int                `json:"level"`
 	ReferrerId openapi_types.UUID `json:"referrer_id"`
+	UserId     openapi_types.UUID `json:"user_id"`
+	Username   string             `json:"username"`
+}
+
+// ReferrerRequest Нужно указать ровно одно из полей
+type ReferrerRequest struct {
+	// ReferralCode Код приглашения реферера, регистр не важен
+	ReferralCode *string             `json:"referral_code,omitempty"`
+	ReferrerId   *openapi_types.UUID `json:"referrer_id,omitempty"`
 }
 
 // ReferrerResponse defines model for ReferrerResponse.
@@ -57,6 +493,57 @@ type ReferrerResponse struct {
 	Status string `json:"status"`
 }
 
+// RevokedTask defines model for RevokedTask.
+type RevokedTask struct {
+	AllowRecomplete bool `json:"allow_recomplete"`
+
+	// BalanceAfter Баланс после списания; отрицательный, если начисленные баллы уже потрачены
+	BalanceAfter int `json:"balance_after"`
+
+	// CommissionsReversed Сумма комиссий, списанных с рефереров вместе с выполнением
+	CommissionsReversed int                 `json:"commissions_reversed"`
+	CompletedAt         time.Time           `json:"completed_at"`
+	Id                  openapi_types.UUID  `json:"id"`
+	PointsDeducted      int                 `json:"points_deducted"`
+	Reason              string              `json:"reason"`
+	RevokedAt           time.Time           `json:"revoked_at"`
+	RevokedBy           *openapi_types.UUID `json:"revoked_by"`
+	TaskId              openapi_types.UUID  `json:"task_id"`
+	UserId              openapi_types.UUID  `json:"user_id"`
+}
+
+// Reward defines model for Reward.
+type Reward struct {
+	Active       bool               `json:"active"`
+	Code         string             `json:"code"`
+	Description  *string            `json:"description,omitempty"`
+	Id           openapi_types.UUID `json:"id"`
+	PerUserLimit *int               `json:"per_user_limit,omitempty"`
+	Price        int                `json:"price"`
+	Stock        *int               `json:"stock,omitempty"`
+	Title        string             `json:"title"`
+}
+
+// RewardRequest defines model for RewardRequest.
+type RewardRequest struct {
+	Active      *bool   `json:"active,omitempty"`
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+
+	// PerUserLimit Сколько раз один пользователь может получить награду
+	PerUserLimit *int `json:"per_user_limit,omitempty"`
+	Price        int  `json:"price"`
+
+	// Stock Остаток, без поля — без ограничения
+	Stock *int   `json:"stock,omitempty"`
+	Title string `json:"title"`
+}
+
+// StatusResponse defines model for StatusResponse.
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
 // TaskCompleteRequest defines model for TaskCompleteRequest.
 type TaskCompleteRequest struct {
 	Metadata *map[string]string `json:"metadata,omitempty"`
@@ -68,27 +555,271 @@ type TaskCompleteResponse struct {
 	Status string `json:"status"`
 }
 
+// TaskImportReport defines model for TaskImportReport.
+type TaskImportReport struct {
+	// Applied Строки применены (false при dry-run или ошибках проверки)
+	Applied   bool                  `json:"applied"`
+	Completed int                   `json:"completed"`
+	DryRun    bool                  `json:"dry_run"`
+	Failed    int                   `json:"failed"`
+	Rows      []TaskImportRowResult `json:"rows"`
+	Skipped   int                   `json:"skipped"`
+	Total     int                   `json:"total"`
+	Valid     int                   `json:"valid"`
+}
+
+// TaskImportRowResult defines model for TaskImportRowResult.
+type TaskImportRowResult struct {
+	Error *string `json:"error,omitempty"`
+
+	// Line Номер строки в файле
+	Line       int                       `json:"line"`
+	Status     TaskImportRowResultStatus `json:"status"`
+	TaskId     *openapi_types.UUID       `json:"task_id,omitempty"`
+	UserId     *openapi_types.UUID       `json:"user_id,omitempty"`
+	UserTaskId *openapi_types.UUID       `json:"user_task_id,omitempty"`
+}
+
+// TaskImportRowResultStatus defines model for TaskImportRowResult.Status.
+type TaskImportRowResultStatus string
+
+// TaskProgress defines model for TaskProgress.
+type TaskProgress struct {
+	Code        string             `json:"code"`
+	Completed   bool               `json:"completed"`
+	Description *string            `json:"description,omitempty"`
+	Progress    int                `json:"progress"`
+	Target      int                `json:"target"`
+	TaskId      openapi_types.UUID `json:"task_id"`
+	Title       *string            `json:"title,omitempty"`
+}
+
+// TaskProgressRequest defines model for TaskProgressRequest.
+type TaskProgressRequest struct {
+	Increment *int               `json:"increment,omitempty"`
+	TaskId    openapi_types.UUID `json:"task_id"`
+}
+
+// TaskRevocationRequest defines model for TaskRevocationRequest.
+type TaskRevocationRequest struct {
+	// AllowRecomplete Разрешить пользователю выполнить задание повторно
+	AllowRecomplete *bool  `json:"allow_recomplete,omitempty"`
+	Reason          string `json:"reason"`
+}
+
+// TaskTranslation defines model for TaskTranslation.
+type TaskTranslation struct {
+	Description *string            `json:"description,omitempty"`
+	Locale      string             `json:"locale"`
+	TaskId      openapi_types.UUID `json:"task_id"`
+	Title       string             `json:"title"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// TaskTranslationRequest defines model for TaskTranslationRequest.
+type TaskTranslationRequest struct {
+	Description *string `json:"description,omitempty"`
+	Title       string  `json:"title"`
+}
+
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	Amount int `json:"amount"`
+
+	// Note Комментарий для получателя
+	Note        *string            `json:"note,omitempty"`
+	RecipientId openapi_types.UUID `json:"recipient_id"`
+}
+
+// UserBadge defines model for UserBadge.
+type UserBadge struct {
+	AwardedAt   time.Time `json:"awarded_at"`
+	Code        string    `json:"code"`
+	Description *string   `json:"description,omitempty"`
+	Title       string    `json:"title"`
+}
+
 // UserStatus defines model for UserStatus.
 type UserStatus struct {
-	CompletedTasks []CompletedTask     `json:"completed_tasks"`
-	Id             openapi_types.UUID  `json:"id"`
-	Points         int                 `json:"points"`
-	ReferrerId     *openapi_types.UUID `json:"referrer_id"`
-	Username       string              `json:"username"`
+	Badges         []UserBadge     `json:"badges"`
+	CompletedTasks []CompletedTask `json:"completed_tasks"`
+
+	// ExpiringSoon Баллы, которые скоро сгорят, раньше сгорающие первыми
+	ExpiringSoon    []ExpiringPoints    `json:"expiring_soon"`
+	Id              openapi_types.UUID  `json:"id"`
+	Points          int                 `json:"points"`
+	ReferralCode    string              `json:"referral_code"`
+	ReferrerId      *openapi_types.UUID `json:"referrer_id"`
+	TasksInProgress []TaskProgress      `json:"tasks_in_progress"`
+	Username        string              `json:"username"`
+}
+
+// PostAdminPointsAdjustmentsParams defines parameters for PostAdminPointsAdjustments.
+type PostAdminPointsAdjustmentsParams struct {
+	// IdempotencyKey Ключ идемпотентности; повтор с тем же ключом возвращает сохранённый результат
+	IdempotencyKey string `json:"Idempotency-Key"`
+}
+
+// PostAdminPointsReconciliationParams defines parameters for PostAdminPointsReconciliation.
+type PostAdminPointsReconciliationParams struct {
+	// Fix Записать недостающие проводки и расхождения балансов с журналом
+	Fix *bool `form:"fix,omitempty" json:"fix,omitempty"`
+}
+
+// GetAdminRedemptionsParams defines parameters for GetAdminRedemptions.
+type GetAdminRedemptionsParams struct {
+	Status *GetAdminRedemptionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetAdminRedemptionsParamsStatus defines parameters for GetAdminRedemptions.
+type GetAdminRedemptionsParamsStatus string
+
+// GetAdminReferralReviewsParams defines parameters for GetAdminReferralReviews.
+type GetAdminReferralReviewsParams struct {
+	Status *GetAdminReferralReviewsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetAdminReferralReviewsParamsStatus defines parameters for GetAdminReferralReviews.
+type GetAdminReferralReviewsParamsStatus string
+
+// PostAdminTaskCompletionsImportParams defines parameters for PostAdminTaskCompletionsImport.
+type PostAdminTaskCompletionsImportParams struct {
+	// DryRun Только проверить строки, ничего не засчитывая
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Format Формат файла, по умолчанию определяется по Content-Type
+	Format *PostAdminTaskCompletionsImportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// PostAdminTaskCompletionsImportParamsFormat defines parameters for PostAdminTaskCompletionsImport.
+type PostAdminTaskCompletionsImportParamsFormat string
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// Category Код категории
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Код тега
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// AcceptLanguage Предпочитаемые локали текстов заданий
+	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 }
 
 // GetUsersLeaderboardParams defines parameters for GetUsersLeaderboard.
 type GetUsersLeaderboardParams struct {
-	Limit  int `form:"limit" json:"limit"`
-	Offset int `form:"offset" json:"offset"`
-	Page   int `form:"page" json:"page"`
+	Period *LeaderboardPeriod `form:"period,omitempty" json:"period,omitempty"`
+	Limit  *int               `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Смещение от начала рейтинга, нельзя передавать вместе с page
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Page Номер страницы размера limit, начиная с 1; нельзя передавать вместе с offset
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// GetUsersLeaderboardAroundIdParams defines parameters for GetUsersLeaderboardAroundId.
+type GetUsersLeaderboardAroundIdParams struct {
+	// Radius Сколько пользователей вернуть выше и ниже заданного
+	Radius *int `form:"radius,omitempty" json:"radius,omitempty"`
+}
+
+// GetUsersIdPointsHistoryParams defines parameters for GetUsersIdPointsHistory.
+type GetUsersIdPointsHistoryParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Reason Причины изменения через запятую (task_completed, task_revoked, ...)
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+
+	// From Начало периода (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetUsersIdReferralsParams defines parameters for GetUsersIdReferrals.
+type GetUsersIdReferralsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersIdReferralsTreeParams defines parameters for GetUsersIdReferralsTree.
+type GetUsersIdReferralsTreeParams struct {
+	// Depth Глубина сети; по умолчанию — число уровней комиссий
+	Depth *int `form:"depth,omitempty" json:"depth,omitempty"`
+}
+
+// GetUsersIdStatusParams defines parameters for GetUsersIdStatus.
+type GetUsersIdStatusParams struct {
+	// AcceptLanguage Предпочитаемые локали текстов заданий
+	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 }
 
+// PostWebhooksPartnerTaskCompletionsParams defines parameters for PostWebhooksPartnerTaskCompletions.
+type PostWebhooksPartnerTaskCompletionsParams struct {
+	// XWebhookTimestamp Unix-время отправки в секундах
+	XWebhookTimestamp string `json:"X-Webhook-Timestamp"`
+
+	// XWebhookSignature hex(HMAC-SHA256(secret, timestamp + "." + body)), допускается префикс sha256=
+	XWebhookSignature string `json:"X-Webhook-Signature"`
+}
+
+// PostAdminMultiplierEventsJSONRequestBody defines body for PostAdminMultiplierEvents for application/json ContentType.
+type PostAdminMultiplierEventsJSONRequestBody = MultiplierEventRequest
+
+// PutAdminPartnersPartnerUsersExternalUserIdJSONRequestBody defines body for PutAdminPartnersPartnerUsersExternalUserId for application/json ContentType.
+type PutAdminPartnersPartnerUsersExternalUserIdJSONRequestBody = PartnerUserLinkRequest
+
+// PostAdminPointsAdjustmentsJSONRequestBody defines body for PostAdminPointsAdjustments for application/json ContentType.
+type PostAdminPointsAdjustmentsJSONRequestBody = PointsAdjustmentRequest
+
+// PostAdminRedemptionsIdCancelJSONRequestBody defines body for PostAdminRedemptionsIdCancel for application/json ContentType.
+type PostAdminRedemptionsIdCancelJSONRequestBody = RedemptionDecisionRequest
+
+// PostAdminRedemptionsIdFulfilJSONRequestBody defines body for PostAdminRedemptionsIdFulfil for application/json ContentType.
+type PostAdminRedemptionsIdFulfilJSONRequestBody = RedemptionDecisionRequest
+
+// PostAdminReferralReviewsIdApproveJSONRequestBody defines body for PostAdminReferralReviewsIdApprove for application/json ContentType.
+type PostAdminReferralReviewsIdApproveJSONRequestBody = ReferralReviewDecisionRequest
+
+// PostAdminReferralReviewsIdRejectJSONRequestBody defines body for PostAdminReferralReviewsIdReject for application/json ContentType.
+type PostAdminReferralReviewsIdRejectJSONRequestBody = ReferralReviewDecisionRequest
+
+// PostAdminRewardsJSONRequestBody defines body for PostAdminRewards for application/json ContentType.
+type PostAdminRewardsJSONRequestBody = RewardRequest
+
+// PutAdminTasksIdTranslationsLocaleJSONRequestBody defines body for PutAdminTasksIdTranslationsLocale for application/json ContentType.
+type PutAdminTasksIdTranslationsLocaleJSONRequestBody = TaskTranslationRequest
+
+// PostAdminUserTasksIdRevokeJSONRequestBody defines body for PostAdminUserTasksIdRevoke for application/json ContentType.
+type PostAdminUserTasksIdRevokeJSONRequestBody = TaskRevocationRequest
+
 // PostUsersAuthJSONRequestBody defines body for PostUsersAuth for application/json ContentType.
 type PostUsersAuthJSONRequestBody = AuthRequest
 
+// PostUsersIdRedemptionsJSONRequestBody defines body for PostUsersIdRedemptions for application/json ContentType.
+type PostUsersIdRedemptionsJSONRequestBody = RedemptionRequest
+
+// PutUsersIdReferralCodeJSONRequestBody defines body for PutUsersIdReferralCode for application/json ContentType.
+type PutUsersIdReferralCodeJSONRequestBody = ReferralCodeRequest
+
 // PostUsersIdReferrerJSONRequestBody defines body for PostUsersIdReferrer for application/json ContentType.
 type PostUsersIdReferrerJSONRequestBody = ReferrerRequest
 
 // PostUsersIdTaskCompleteJSONRequestBody defines body for PostUsersIdTaskComplete for application/json ContentType.
 type PostUsersIdTaskCompleteJSONRequestBody = TaskCompleteRequest
+
+// PostUsersIdTaskProgressJSONRequestBody defines body for PostUsersIdTaskProgress for application/json ContentType.
+type PostUsersIdTaskProgressJSONRequestBody = TaskProgressRequest
+
+// PostUsersIdTransfersJSONRequestBody defines body for PostUsersIdTransfers for application/json ContentType.
+type PostUsersIdTransfersJSONRequestBody = TransferRequest
+
+// PostWebhooksPartnerTaskCompletionsJSONRequestBody defines body for PostWebhooksPartnerTaskCompletions for application/json ContentType.
+type PostWebhooksPartnerTaskCompletionsJSONRequestBody = PartnerTaskCompletionRequest
@@ -0,0 +1,49 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor возвращается для повреждённого или чужого курсора
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor - позиция в выборке, упорядоченной по (время, id)
+type Cursor struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func Encode(c Cursor) string {
+	raw := c.At.Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode разбирает строку, полученную из Encode
+func Decode(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{At: t, ID: u}, nil
+}
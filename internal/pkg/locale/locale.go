@@ -0,0 +1,81 @@
+package locale
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Normalize приводит языковой тег к нижнему регистру и проверяет формат
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if !tagPattern.MatchString(tag) {
+		return "", false
+	}
+	return tag, true
+}
+
+// FromRequest возвращает локали из заголовка Accept-Language в порядке предпочтения.
+// После региональной локали добавляется базовый язык: "en-US" → "en-us", "en".
+func FromRequest(r *http.Request) []string {
+	return Parse(r.Header.Get("Accept-Language"))
+}
+
+// Parse разбирает значение Accept-Language, отбрасывая "*", q=0 и некорректные теги
+func Parse(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var parsed []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+
+		tag, ok := Normalize(fields[0])
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || name != "q" {
+				continue
+			}
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				q = v
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		parsed = append(parsed, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool { return parsed[i].q > parsed[j].q })
+
+	seen := make(map[string]struct{}, len(parsed))
+	locales := make([]string, 0, len(parsed))
+	add := func(tag string) {
+		if _, ok := seen[tag]; ok {
+			return
+		}
+		seen[tag] = struct{}{}
+		locales = append(locales, tag)
+	}
+
+	for _, p := range parsed {
+		add(p.tag)
+		if base, _, found := strings.Cut(p.tag, "-"); found {
+			add(base)
+		}
+	}
+
+	return locales
+}
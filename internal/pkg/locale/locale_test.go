@@ -0,0 +1,48 @@
+package locale
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{tag: "ru", want: "ru", ok: true},
+		{tag: " en-US ", want: "en-us", ok: true},
+		{tag: "pt_BR", want: "pt-br", ok: true},
+		{tag: "zh-Hant-TW", want: "zh-hant-tw", ok: true},
+		{tag: "", ok: false},
+		{tag: "*", ok: false},
+		{tag: "english", ok: false},
+		{tag: "en-", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Normalize(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Normalize(%q) = %q, %t; want %q, %t", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "en-US,en;q=0.9,ru;q=0.8", want: []string{"en-us", "en", "ru"}},
+		{header: "ru;q=0.5, de-DE", want: []string{"de-de", "de", "ru"}},
+		{header: "fr;q=0, *, es", want: []string{"es"}},
+	}
+
+	for _, tt := range tests {
+		if got := Parse(tt.header); !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package adminauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"service-boilerplate-go/internal/pkg/middleware/jwtauth"
+	"service-boilerplate-go/internal/pkg/response"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+type Logger interface {
+	Error(ctx context.Context, msg string)
+	Warn(ctx context.Context, msg string)
+
+	WithFields(ctx context.Context, fields map[string]any) context.Context
+}
+
+type Service interface {
+	IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// Middleware пропускает только пользователей с ролью администратора.
+// Должен стоять после jwtauth.Middleware.
+func Middleware(logger Logger, service Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			userIDStr, ok := jwtauth.UserIDFromContext(ctx)
+			if !ok {
+				logger.Warn(ctx, "unauthorized: no user id in context")
+				response.ErrorStatus(w, http.StatusUnauthorized)
+				return
+			}
+
+			ctx = logger.WithFields(ctx, map[string]any{
+				"admin_id": userIDStr,
+			})
+
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				logger.Warn(ctx, "unauthorized: invalid user id in token")
+				response.ErrorStatus(w, http.StatusUnauthorized)
+				return
+			}
+
+			isAdmin, err := service.IsAdmin(ctx, userID)
+			if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+				ctx = logger.WithFields(ctx, map[string]any{
+					"error": err.Error(),
+				})
+				logger.Error(ctx, "failed to check admin role")
+				response.ErrorStatus(w, http.StatusInternalServerError)
+				return
+			}
+
+			if !isAdmin {
+				logger.Warn(ctx, "forbidden: user is not an admin")
+				response.ErrorStatus(w, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package refcode
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	// alphabet — символы сгенерированных кодов; без 0/O и 1/I/L, которые путают на слух и при наборе
+	alphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+	// length — длина сгенерированного кода. Алфавит и длину повторяет заполнение
+	// кодов в миграции referral_codes
+	length = 8
+
+	// MinVanityLength и MaxVanityLength — допустимая длина кода, выбранного пользователем
+	MinVanityLength = 4
+	MaxVanityLength = 16
+)
+
+// Generate возвращает случайный код из alphabet
+func Generate() (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	// 256 не делится на len(alphabet), смещение распределения для кодов приглашений не важно
+	for i, b := range buf {
+		buf[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return string(buf), nil
+}
+
+// Normalize приводит код к каноническому виду, в котором он хранится и сравнивается
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidVanity проверяет нормализованный код, выбранный пользователем:
+// латинские буквы, цифры и дефис не по краям
+func ValidVanity(code string) bool {
+	if len(code) < MinVanityLength || len(code) > MaxVanityLength {
+		return false
+	}
+	if code[0] == '-' || code[len(code)-1] == '-' {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -26,8 +26,14 @@ func ErrorStatus(w http.ResponseWriter, status int) {
 		resp.Errors = "bad request"
 	case http.StatusUnauthorized:
 		resp.Errors = "unauthorized"
+	case http.StatusForbidden:
+		resp.Errors = "forbidden"
 	case http.StatusNotFound:
 		resp.Errors = "not found"
+	case http.StatusConflict:
+		resp.Errors = "conflict"
+	case http.StatusUnprocessableEntity:
+		resp.Errors = "unprocessable entity"
 	default:
 		resp.Errors = "internal server error"
 		status = http.StatusInternalServerError
@@ -41,10 +47,57 @@ func ErrorDomain(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, entities.ErrUserNotFound), errors.Is(err, entities.ErrTaskNotFound):
 		ErrorStatus(w, http.StatusNotFound)
-	case errors.Is(err, entities.ErrInvalidCredentials):
+	case errors.Is(err, entities.ErrTaskTranslationNotFound), errors.Is(err, entities.ErrTaskCategoryNotFound),
+		errors.Is(err, entities.ErrUserTaskNotFound):
+		ErrorStatus(w, http.StatusNotFound)
+	case errors.Is(err, entities.ErrUnknownPartner), errors.Is(err, entities.ErrPartnerUserNotFound):
+		ErrorStatus(w, http.StatusNotFound)
+	case errors.Is(err, entities.ErrInvalidCredentials), errors.Is(err, entities.ErrInvalidWebhookSignature):
 		ErrorStatus(w, http.StatusUnauthorized)
+	case errors.Is(err, entities.ErrForbidden):
+		ErrorStatus(w, http.StatusForbidden)
 	case errors.Is(err, entities.ErrReferrerAlreadySet), errors.Is(err, entities.ErrTaskAlreadyCompleted):
 		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrTaskProgressRequired),
+		errors.Is(err, entities.ErrTaskNotProgressBased),
+		errors.Is(err, entities.ErrTaskProgressManaged):
+		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrTaskAlreadyRevoked):
+		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrWebhookReplay), errors.Is(err, entities.ErrPartnerUserAlreadyLinked):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrTaskCapReached):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrRewardNotFound), errors.Is(err, entities.ErrRedemptionNotFound):
+		ErrorStatus(w, http.StatusNotFound)
+	case errors.Is(err, entities.ErrInsufficientPoints), errors.Is(err, entities.ErrRewardOutOfStock),
+		errors.Is(err, entities.ErrRewardLimitReached), errors.Is(err, entities.ErrRewardAlreadyExists),
+		errors.Is(err, entities.ErrRedemptionNotPending):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrTransferToSelf):
+		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrAccountTooNew):
+		ErrorStatus(w, http.StatusForbidden)
+	case errors.Is(err, entities.ErrTransferLimitExceeded):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrIdempotencyKeyReused):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrReferralCodeNotFound):
+		ErrorStatus(w, http.StatusNotFound)
+	case errors.Is(err, entities.ErrReferralCodeTaken):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrInvalidReferralCode):
+		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrSelfReferral):
+		ErrorStatus(w, http.StatusBadRequest)
+	case errors.Is(err, entities.ErrReferralCycle):
+		ErrorStatus(w, http.StatusConflict)
+	case errors.Is(err, entities.ErrReferrerNotOlder):
+		ErrorStatus(w, http.StatusUnprocessableEntity)
+	case errors.Is(err, entities.ErrReferralReviewNotFound):
+		ErrorStatus(w, http.StatusNotFound)
+	case errors.Is(err, entities.ErrReferralReviewNotPending):
+		ErrorStatus(w, http.StatusConflict)
 
 	default:
 		ErrorStatus(w, http.StatusInternalServerError)
@@ -0,0 +1,183 @@
+package taskimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// Format - формат файла импорта
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+const (
+	columnUserID   = "user_id"
+	columnUsername = "username"
+	columnTaskCode = "task_code"
+)
+
+// ErrUnknownFormat возвращается для неподдерживаемого формата файла
+var ErrUnknownFormat = errors.New("unknown import format")
+
+// ParseFormat определяет формат по имени ("csv", "jsonl"), MIME-типу или расширению файла
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+
+	switch {
+	case s == "csv", s == "text/csv", strings.HasSuffix(s, ".csv"):
+		return FormatCSV, nil
+	case s == "jsonl", s == "ndjson", s == "application/x-ndjson", s == "application/jsonl",
+		strings.HasSuffix(s, ".jsonl"), strings.HasSuffix(s, ".ndjson"):
+		return FormatJSONL, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+}
+
+// Parse читает строки импорта в заданном формате
+func Parse(r io.Reader, format Format) ([]entities.TaskImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatJSONL:
+		return ParseJSONL(r)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// ParseCSV читает CSV с заголовком. Обязательна колонка task_code и одна из
+// колонок user_id или username; остальные непустые колонки попадают в метадату.
+func ParseCSV(r io.Reader) ([]entities.TaskImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv: missing header")
+		}
+		return nil, fmt.Errorf("csv: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("csv: duplicate column %q", name)
+		}
+		header[i] = name
+		columns[name] = i
+	}
+
+	if _, ok := columns[columnTaskCode]; !ok {
+		return nil, fmt.Errorf("csv: missing column %q", columnTaskCode)
+	}
+	_, hasUserID := columns[columnUserID]
+	_, hasUsername := columns[columnUsername]
+	if !hasUserID && !hasUsername {
+		return nil, fmt.Errorf("csv: missing column %q or %q", columnUserID, columnUsername)
+	}
+
+	var rows []entities.TaskImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := entities.TaskImportRow{Line: line}
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			switch header[i] {
+			case columnUserID:
+				if value == "" {
+					continue
+				}
+				id, err := uuid.Parse(value)
+				if err != nil {
+					return nil, fmt.Errorf("csv: line %d: invalid user_id: %w", line, err)
+				}
+				row.UserID = &id
+			case columnUsername:
+				row.Username = value
+			case columnTaskCode:
+				row.TaskCode = value
+			default:
+				if value == "" {
+					continue
+				}
+				if row.Metadata == nil {
+					row.Metadata = make(map[string]string)
+				}
+				row.Metadata[header[i]] = value
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// jsonlRow — строка JSONL-файла импорта
+type jsonlRow struct {
+	UserID   *uuid.UUID        `json:"user_id"`
+	Username string            `json:"username"`
+	TaskCode string            `json:"task_code"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ParseJSONL читает по одному JSON-объекту на строку, пустые строки пропускаются
+func ParseJSONL(r io.Reader) ([]entities.TaskImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var rows []entities.TaskImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var v jsonlRow
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("jsonl: line %d: %w", line, err)
+		}
+
+		rows = append(rows, entities.TaskImportRow{
+			Line:     line,
+			UserID:   v.UserID,
+			Username: strings.TrimSpace(v.Username),
+			TaskCode: strings.TrimSpace(v.TaskCode),
+			Metadata: v.Metadata,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("jsonl: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// AdjustPoints применяет набор ручных корректировок баланса в одной транзакции.
+// Повтор с тем же ключом идемпотентности возвращает сохранённый результат,
+// а ключ с другим содержимым отклоняется с ErrIdempotencyKeyReused.
+// Списания не могут увести баланс в минус.
+func (s *Service) AdjustPoints(ctx context.Context, batch entities.PointsAdjustmentBatch) (*entities.PointsAdjustmentBatch, error) {
+	batch.RequestHash = adjustmentRequestHash(batch)
+
+	var result *entities.PointsAdjustmentBatch
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		created, err := s.storage.CreateAdjustmentBatch(ctx, batch.IdempotencyKey, batch.RequestHash)
+		if err != nil {
+			return err
+		}
+		if !created {
+			existing, err := s.storage.GetAdjustmentBatch(ctx, batch.IdempotencyKey)
+			if err != nil {
+				return err
+			}
+			if existing == nil || existing.RequestHash != batch.RequestHash {
+				return entities.ErrIdempotencyKeyReused
+			}
+			existing.Replayed = true
+			result = existing
+			return nil
+		}
+
+		// блокируем всех пользователей набора заранее в порядке id,
+		// чтобы встречные наборы не блокировали друг друга
+		userIDs := make([]uuid.UUID, 0, len(batch.Adjustments))
+		seen := make(map[uuid.UUID]struct{}, len(batch.Adjustments))
+		for _, a := range batch.Adjustments {
+			if _, ok := seen[a.UserID]; !ok {
+				seen[a.UserID] = struct{}{}
+				userIDs = append(userIDs, a.UserID)
+			}
+		}
+		locked, err := s.storage.LockUsers(ctx, userIDs...)
+		if err != nil {
+			return err
+		}
+		if len(locked) != len(userIDs) {
+			return entities.ErrUserNotFound
+		}
+
+		result = &batch
+		for i := range batch.Adjustments {
+			adjustment := &batch.Adjustments[i]
+			adjustment.ID = uuid.New()
+			adjustment.AdminID = batch.AdminID
+
+			if adjustment.Amount > 0 {
+				err = s.postLedgerEntry(ctx, adjustment.UserID, adjustment.Amount, entities.LedgerReasonAdminAdjustment, &adjustment.ID)
+			} else {
+				err = s.spendPoints(ctx, adjustment.UserID, -adjustment.Amount, entities.LedgerReasonAdminAdjustment, &adjustment.ID)
+			}
+			if err != nil {
+				return err
+			}
+
+			saved, err := s.storage.CreateAdjustment(ctx, batch.IdempotencyKey, i, *adjustment)
+			if err != nil {
+				return err
+			}
+			*adjustment = *saved
+
+			if adjustment.Amount > 0 {
+				if err := s.handleBadgeEvents(ctx, adjustment.UserID, entities.BadgeEventPointsChanged); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// adjustmentRequestHash считает отпечаток набора: администратор и корректировки по порядку
+func adjustmentRequestHash(batch entities.PointsAdjustmentBatch) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", batch.AdminID)
+	for _, a := range batch.Adjustments {
+		fmt.Fprintf(h, "%s\x00%d\x00%s\x00%q\n", a.UserID, a.Amount, a.ReasonCode, a.Note)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
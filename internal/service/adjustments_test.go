@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestAdjustPointsIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	adminID, userID := st.addUser(nil), st.addUser(nil)
+
+	batch := entities.PointsAdjustmentBatch{
+		IdempotencyKey: "key-1",
+		AdminID:        adminID,
+		Adjustments: []entities.PointsAdjustment{
+			{UserID: userID, Amount: 100, ReasonCode: entities.AdjustmentReasonGoodwill, Note: "outage"},
+			{UserID: userID, Amount: -30, ReasonCode: entities.AdjustmentReasonCorrection},
+		},
+	}
+
+	first, err := s.AdjustPoints(ctx, batch)
+	if err != nil {
+		t.Fatalf("AdjustPoints() error = %v", err)
+	}
+	if first.Replayed {
+		t.Fatal("first AdjustPoints() is marked as replayed")
+	}
+
+	second, err := s.AdjustPoints(ctx, batch)
+	if err != nil {
+		t.Fatalf("AdjustPoints() replay error = %v", err)
+	}
+	if !second.Replayed || len(second.Adjustments) != 2 {
+		t.Fatalf("replay = %+v, want replayed batch with 2 adjustments", second)
+	}
+	for i := range first.Adjustments {
+		if second.Adjustments[i].ID != first.Adjustments[i].ID {
+			t.Fatalf("replayed adjustment %d id = %s, want %s", i, second.Adjustments[i].ID, first.Adjustments[i].ID)
+		}
+	}
+
+	if got := st.balance(t, userID); got != 70 {
+		t.Fatalf("balance = %d, want 70", got)
+	}
+	if got := len(st.entries(userID, entities.LedgerReasonAdminAdjustment)); got != 2 {
+		t.Fatalf("admin_adjustment entries = %d, want 2", got)
+	}
+}
+
+func TestAdjustPointsRejectsReusedKey(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	adminID, userID := st.addUser(nil), st.addUser(nil)
+
+	batch := entities.PointsAdjustmentBatch{
+		IdempotencyKey: "key-1",
+		AdminID:        adminID,
+		Adjustments:    []entities.PointsAdjustment{{UserID: userID, Amount: 100, ReasonCode: entities.AdjustmentReasonGoodwill}},
+	}
+	if _, err := s.AdjustPoints(ctx, batch); err != nil {
+		t.Fatalf("AdjustPoints() error = %v", err)
+	}
+
+	batch.Adjustments = []entities.PointsAdjustment{{UserID: userID, Amount: 200, ReasonCode: entities.AdjustmentReasonGoodwill}}
+	if _, err := s.AdjustPoints(ctx, batch); !errors.Is(err, entities.ErrIdempotencyKeyReused) {
+		t.Fatalf("AdjustPoints() error = %v, want %v", err, entities.ErrIdempotencyKeyReused)
+	}
+	if got := st.balance(t, userID); got != 100 {
+		t.Fatalf("balance = %d, want 100", got)
+	}
+}
+
+func TestAdjustPointsAppliesBatchAtomically(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	adminID, first, second := st.addUser(nil), st.addUser(nil), st.addUser(nil)
+
+	batch := entities.PointsAdjustmentBatch{
+		IdempotencyKey: "key-1",
+		AdminID:        adminID,
+		Adjustments: []entities.PointsAdjustment{
+			{UserID: first, Amount: 100, ReasonCode: entities.AdjustmentReasonGoodwill},
+			{UserID: second, Amount: -1, ReasonCode: entities.AdjustmentReasonCorrection},
+		},
+	}
+	if _, err := s.AdjustPoints(ctx, batch); !errors.Is(err, entities.ErrInsufficientPoints) {
+		t.Fatalf("AdjustPoints() error = %v, want %v", err, entities.ErrInsufficientPoints)
+	}
+	if got := st.balance(t, first); got != 0 {
+		t.Fatalf("balance after failed batch = %d, want 0", got)
+	}
+
+	// ключ отказавшего набора не занят, исправленный набор применяется
+	batch.Adjustments[1].Amount = 1
+	if _, err := s.AdjustPoints(ctx, batch); err != nil {
+		t.Fatalf("AdjustPoints() error = %v", err)
+	}
+
+	batch.IdempotencyKey = "key-2"
+	batch.Adjustments = []entities.PointsAdjustment{{UserID: uuid.New(), Amount: 1, ReasonCode: entities.AdjustmentReasonGoodwill}}
+	if _, err := s.AdjustPoints(ctx, batch); !errors.Is(err, entities.ErrUserNotFound) {
+		t.Fatalf("AdjustPoints() unknown user error = %v, want %v", err, entities.ErrUserNotFound)
+	}
+}
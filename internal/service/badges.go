@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// GetUserBadges возвращает достижения пользователя
+func (s *Service) GetUserBadges(ctx context.Context, userID uuid.UUID) ([]entities.UserBadge, error) {
+	exists, err := s.storage.IsUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return s.storage.GetUserBadges(ctx, userID)
+}
+
+// handleBadgeEvents проверяет правила достижений, подписанные на события, и выдаёт
+// выполненные. Выдача идемпотентна: уже полученные достижения не проверяются.
+// Должна вызываться внутри транзакции, вместе с изменением, породившим событие.
+func (s *Service) handleBadgeEvents(ctx context.Context, userID uuid.UUID, events ...entities.BadgeEvent) error {
+	// показатели считаются один раз на вызов, даже если их использует несколько правил
+	metrics := make(map[entities.BadgeMetric]int)
+
+	for _, event := range events {
+		badges, err := s.storage.GetPendingBadges(ctx, userID, event)
+		if err != nil {
+			return err
+		}
+
+		for _, badge := range badges {
+			satisfied, err := s.isBadgeSatisfied(ctx, userID, badge, metrics)
+			if err != nil {
+				return err
+			}
+			if !satisfied {
+				continue
+			}
+
+			if _, err := s.storage.AwardBadge(ctx, userID, badge.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isBadgeSatisfied проверяет правило достижения. Место в рейтинге целиком не считается:
+// баланс пользователя сравнивается с балансом на пороговом месте, что стоит
+// O(порога), а не O(места пользователя). Остальные показатели кешируются в metrics.
+func (s *Service) isBadgeSatisfied(ctx context.Context, userID uuid.UUID, badge *entities.Badge, metrics map[entities.BadgeMetric]int) (bool, error) {
+	if badge.Metric == entities.BadgeMetricLeaderboardRank {
+		if badge.Threshold <= 0 {
+			return false, nil
+		}
+		return s.storage.IsWithinLeaderboardRank(ctx, userID, badge.Threshold)
+	}
+
+	value, ok := metrics[badge.Metric]
+	if !ok {
+		var err error
+		value, err = s.storage.GetUserBadgeMetric(ctx, userID, badge.Metric)
+		if err != nil {
+			return false, err
+		}
+		metrics[badge.Metric] = value
+	}
+
+	return badge.IsSatisfied(value), nil
+}
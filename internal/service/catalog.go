@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// ListTasks возвращает каталог задач, тексты подбираются по локалям с откатом на локаль по умолчанию
+func (s *Service) ListTasks(ctx context.Context, filter entities.TaskFilter, locales []string) ([]*entities.Task, error) {
+	return s.storage.ListTasks(ctx, filter, s.withDefaultLocale(locales))
+}
+
+func (s *Service) GetTaskTranslations(ctx context.Context, taskID uuid.UUID) ([]entities.TaskTranslation, error) {
+	if _, err := s.storage.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.storage.GetTaskTranslations(ctx, taskID)
+}
+
+func (s *Service) UpsertTaskTranslation(ctx context.Context, tr entities.TaskTranslation) (*entities.TaskTranslation, error) {
+	if _, err := s.storage.GetTaskByID(ctx, tr.TaskID); err != nil {
+		return nil, err
+	}
+	return s.storage.UpsertTaskTranslation(ctx, tr)
+}
+
+func (s *Service) DeleteTaskTranslation(ctx context.Context, taskID uuid.UUID, locale string) error {
+	return s.storage.DeleteTaskTranslation(ctx, taskID, locale)
+}
+
+// withDefaultLocale добавляет локаль по умолчанию в конец списка предпочтений
+func (s *Service) withDefaultLocale(locales []string) []string {
+	if slices.Contains(locales, s.defaultLocale) {
+		return locales
+	}
+	return append(slices.Clip(locales), s.defaultLocale)
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdjustmentReason - код причины ручной корректировки баланса
+type AdjustmentReason string
+
+const (
+	AdjustmentReasonCompensation AdjustmentReason = "compensation" // компенсация за сбой или ошибку сервиса
+	AdjustmentReasonCorrection   AdjustmentReason = "correction"   // исправление ошибочного начисления или списания
+	AdjustmentReasonGoodwill     AdjustmentReason = "goodwill"     // начисление по решению поддержки
+	AdjustmentReasonFraud        AdjustmentReason = "fraud"        // списание баллов, полученных нечестно
+)
+
+// PointsAdjustment - ручная корректировка баланса администратором
+type PointsAdjustment struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	AdminID    uuid.UUID
+	Amount     int // положительное — начисление, отрицательное — списание
+	ReasonCode AdjustmentReason
+	Note       string
+	CreatedAt  time.Time
+}
+
+// PointsAdjustmentBatch - набор корректировок одного запроса.
+// Применяется целиком; повтор с тем же ключом возвращает сохранённый результат.
+type PointsAdjustmentBatch struct {
+	IdempotencyKey string
+	AdminID        uuid.UUID
+	Adjustments    []PointsAdjustment
+	RequestHash    string // отпечаток содержимого запроса, отличает повтор от переиспользования ключа
+	Replayed       bool   // результат сохранён предыдущим запросом с тем же ключом
+	CreatedAt      time.Time
+}
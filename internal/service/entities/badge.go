@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BadgeEvent - доменное событие, при котором проверяются правила достижений
+type BadgeEvent string
+
+const (
+	BadgeEventTaskCompleted BadgeEvent = "task_completed"
+	BadgeEventReferrerSet   BadgeEvent = "referrer_set"
+	BadgeEventPointsChanged BadgeEvent = "points_changed"
+)
+
+// BadgeMetric - показатель пользователя, который сравнивается с порогом правила
+type BadgeMetric string
+
+const (
+	BadgeMetricCompletedTasks  BadgeMetric = "completed_tasks"  // число действующих выполнений
+	BadgeMetricReferrals       BadgeMetric = "referrals"        // число приглашённых пользователей
+	BadgeMetricPoints          BadgeMetric = "points"           // текущий баланс
+	BadgeMetricLeaderboardRank BadgeMetric = "leaderboard_rank" // место в общем рейтинге, 1 — первое
+)
+
+// Badge - достижение с декларативным правилом выдачи:
+// при событии Event показатель Metric должен достичь порога Threshold
+type Badge struct {
+	ID          uuid.UUID
+	Code        string
+	Title       string
+	Description string
+	Event       BadgeEvent
+	Metric      BadgeMetric
+	Threshold   int
+	CreatedAt   time.Time
+}
+
+// IsSatisfied сообщает, выполняется ли правило для значения показателя.
+// Для места в рейтинге порог — худшее допустимое место, для остальных — минимум.
+func (b *Badge) IsSatisfied(value int) bool {
+	if b.Metric == BadgeMetricLeaderboardRank {
+		return value > 0 && value <= b.Threshold
+	}
+	return value >= b.Threshold
+}
+
+// UserBadge - достижение, выданное пользователю
+type UserBadge struct {
+	Badge
+	AwardedAt time.Time
+}
@@ -6,8 +6,54 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrReferrerAlreadySet = errors.New("referrer already set")
+	ErrForbidden          = errors.New("forbidden")
 
 	ErrTaskNotFound              = errors.New("task not found")
 	ErrTaskAlreadyCompleted      = errors.New("task already completed")
 	ErrTaskMetadataAlreadyExists = errors.New("task metadata already exists")
+	ErrTaskProgressRequired      = errors.New("task is completed by progress")
+	ErrTaskNotProgressBased      = errors.New("task has no progress target")
+	ErrTaskProgressManaged       = errors.New("task progress is tracked automatically")
+	ErrTaskTranslationNotFound   = errors.New("task translation not found")
+	ErrTaskCategoryNotFound      = errors.New("task category not found")
+	ErrTaskCapReached            = errors.New("task completion cap reached")
+
+	ErrUserTaskNotFound   = errors.New("task completion not found")
+	ErrTaskAlreadyRevoked = errors.New("task completion already revoked")
+
+	ErrUnknownPartner           = errors.New("unknown partner")
+	ErrInvalidWebhookSignature  = errors.New("invalid webhook signature")
+	ErrWebhookReplay            = errors.New("webhook nonce already used")
+	ErrPartnerUserNotFound      = errors.New("partner user not linked")
+	ErrPartnerUserAlreadyLinked = errors.New("user already linked to another partner account")
+
+	ErrInsufficientPoints   = errors.New("insufficient points")
+	ErrRewardNotFound       = errors.New("reward not found")
+	ErrRewardAlreadyExists  = errors.New("reward already exists")
+	ErrRewardOutOfStock     = errors.New("reward out of stock")
+	ErrRewardLimitReached   = errors.New("reward per-user limit reached")
+	ErrRedemptionNotFound   = errors.New("redemption not found")
+	ErrRedemptionNotPending = errors.New("redemption already processed")
+
+	ErrTransferToSelf        = errors.New("cannot transfer points to yourself")
+	ErrTransferLimitExceeded = errors.New("daily transfer limit exceeded")
+	ErrAccountTooNew         = errors.New("account is too new for transfers")
+
+	ErrReferralCodeNotFound = errors.New("referral code not found")
+	ErrReferralCodeTaken    = errors.New("referral code already taken")
+	ErrInvalidReferralCode  = errors.New("invalid referral code")
+
+	ErrSelfReferral     = errors.New("user cannot refer themselves")
+	ErrReferralCycle    = errors.New("referrer is already referred by the user")
+	ErrReferrerNotOlder = errors.New("referrer registered after the user")
+
+	ErrReferralReviewNotFound   = errors.New("referral review not found")
+	ErrReferralReviewNotPending = errors.New("referral review already decided")
+
+	ErrIdempotencyKeyReused = errors.New("idempotency key already used for a different request")
+
+	ErrImportUserRequired     = errors.New("user_id or username is required")
+	ErrImportTaskCodeRequired = errors.New("task_code is required")
+	ErrImportUserMismatch     = errors.New("user_id does not match username")
+	ErrImportDuplicateRow     = errors.New("duplicate row")
 )
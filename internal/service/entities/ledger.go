@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LedgerReason - причина изменения баланса
+type LedgerReason string
+
+const (
+	LedgerReasonOpeningBalance             LedgerReason = "opening_balance"              // перенос баланса, накопленного до появления журнала
+	LedgerReasonTaskCompleted              LedgerReason = "task_completed"               // начисление за выполнение, reference — user_tasks.id
+	LedgerReasonTaskRevoked                LedgerReason = "task_revoked"                 // списание при отзыве выполнения, reference — user_tasks.id
+	LedgerReasonRedemption                 LedgerReason = "redemption"                   // покупка награды, reference — reward_redemptions.id
+	LedgerReasonRedemptionRefund           LedgerReason = "redemption_refund"            // возврат при отмене заказа, reference — reward_redemptions.id
+	LedgerReasonTransferOut                LedgerReason = "transfer_out"                 // перевод другому пользователю, reference — points_transfers.id
+	LedgerReasonTransferIn                 LedgerReason = "transfer_in"                  // перевод от другого пользователя, reference — points_transfers.id
+	LedgerReasonPointsExpired              LedgerReason = "points_expired"               // сгорание остатка партии, reference — points_lots.id
+	LedgerReasonAdminAdjustment            LedgerReason = "admin_adjustment"             // ручная корректировка, reference — points_adjustments.id
+	LedgerReasonRefereeBonus               LedgerReason = "referee_bonus"                // бонус за указание реферера, reference — users.id приглашённого
+	LedgerReasonReferrerBonus              LedgerReason = "referrer_bonus"               // бонус рефереру за приглашённого, reference — users.id приглашённого
+	LedgerReasonReferralCommission         LedgerReason = "referral_commission"          // комиссия с начисления участника сети, reference — referral_commissions.id
+	LedgerReasonReferralCommissionReversed LedgerReason = "referral_commission_reversed" // списание комиссии при отзыве начисления, reference — referral_commissions.id
+	LedgerReasonReconciliation             LedgerReason = "reconciliation"               // исправление сверки до ожидаемого баланса; reference нет
+)
+
+// creditReasons — причины, с которыми проводка может начислять баллы и создавать партию
+var creditReasons = map[LedgerReason]bool{
+	LedgerReasonOpeningBalance:     true,
+	LedgerReasonTaskCompleted:      true,
+	LedgerReasonRedemptionRefund:   true,
+	LedgerReasonTransferIn:         true,
+	LedgerReasonAdminAdjustment:    true,
+	LedgerReasonRefereeBonus:       true,
+	LedgerReasonReferrerBonus:      true,
+	LedgerReasonReferralCommission: true,
+	LedgerReasonReconciliation:     true,
+}
+
+// IsCredit сообщает, может ли проводка с этой причиной начислять баллы
+func (r LedgerReason) IsCredit() bool {
+	return creditReasons[r]
+}
+
+// LedgerEntry - запись журнала баллов. Журнал только дополняется,
+// users.points — кеш суммы записей пользователя.
+type LedgerEntry struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Amount       int // положительное — начисление, отрицательное — списание
+	Reason       LedgerReason
+	ReferenceID  *uuid.UUID // объект, вызвавший изменение; nil, если его нет
+	BalanceAfter int
+	TaskCode     string    // код задачи для записей по выполнениям, иначе пусто
+	CreatedAt    time.Time // при записи: нулевое — текущее время
+}
+
+// LedgerFilter - фильтр истории баллов, пустые поля не ограничивают выборку
+type LedgerFilter struct {
+	Reasons []LedgerReason
+	From    *time.Time // включительно
+	To      *time.Time // не включительно
+	After   *LedgerPosition
+	Limit   int
+}
+
+// LedgerPosition - позиция записи в истории, новые записи идут первыми
+type LedgerPosition struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// LedgerPage - страница истории баллов
+type LedgerPage struct {
+	Entries []LedgerEntry
+	Next    *LedgerPosition // nil, если записей больше нет
+}
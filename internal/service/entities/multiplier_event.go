@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxMultiplier - наибольший множитель, который помещается в NUMERIC(6, 2)
+const MaxMultiplier = 9999.99
+
+// MultiplierEvent - временное событие, умножающее награду за задачи.
+// Без задач и категорий действует на все задачи.
+type MultiplierEvent struct {
+	ID         uuid.UUID
+	Name       string
+	Multiplier float64
+	StartsAt   time.Time
+	EndsAt     time.Time
+	TaskIDs    []uuid.UUID
+	Categories []string // коды категорий
+	CreatedAt  time.Time
+}
+
+// TaskReward - начисление за выполнение задачи
+type TaskReward struct {
+	BasePoints int        // награда задачи без множителя
+	Multiplier float64    // применённый множитель, 1 — без буста
+	EventID    *uuid.UUID // событие, давшее множитель
+	Points     int        // итоговое начисление
+}
+
+// NewTaskReward рассчитывает начисление за задачу с учётом события-множителя (может быть nil)
+func NewTaskReward(task *Task, event *MultiplierEvent) TaskReward {
+	reward := TaskReward{
+		BasePoints: task.RewardPoints,
+		Multiplier: 1,
+		Points:     task.RewardPoints,
+	}
+	if event == nil {
+		return reward
+	}
+
+	// множитель хранится с точностью до сотых, считаем в целых сотых,
+	// чтобы начисление совпадало с сохранённым множителем и не зависело от ошибок float
+	hundredths := MultiplierHundredths(event.Multiplier)
+	reward.Multiplier = float64(hundredths) / 100
+	reward.EventID = &event.ID
+	reward.Points = (task.RewardPoints*hundredths + 50) / 100
+	return reward
+}
+
+// MultiplierHundredths возвращает множитель в сотых, как он хранится в NUMERIC(6, 2)
+func MultiplierHundredths(multiplier float64) int {
+	return int(math.Round(multiplier * 100))
+}
+
+// IsValidMultiplier сообщает, что множитель положителен, помещается в NUMERIC(6, 2)
+// и не содержит знаков после сотых, которые БД отбросила бы при сохранении
+func IsValidMultiplier(multiplier float64) bool {
+	if multiplier <= 0 || multiplier > MaxMultiplier {
+		return false
+	}
+	return float64(MultiplierHundredths(multiplier))/100 == multiplier
+}
@@ -0,0 +1,56 @@
+package entities
+
+import "testing"
+
+func TestIsValidMultiplier(t *testing.T) {
+	tests := []struct {
+		multiplier float64
+		want       bool
+	}{
+		{multiplier: 1, want: true},
+		{multiplier: 1.5, want: true},
+		{multiplier: 0.01, want: true},
+		{multiplier: 2.35, want: true},
+		{multiplier: MaxMultiplier, want: true},
+		{multiplier: 0, want: false},
+		{multiplier: -1, want: false},
+		{multiplier: 1.005, want: false},
+		{multiplier: 1.333, want: false},
+		{multiplier: 10000, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidMultiplier(tt.multiplier); got != tt.want {
+			t.Errorf("IsValidMultiplier(%v) = %t, want %t", tt.multiplier, got, tt.want)
+		}
+	}
+}
+
+func TestNewTaskReward(t *testing.T) {
+	tests := []struct {
+		name       string
+		points     int
+		event      *MultiplierEvent
+		wantPoints int
+		wantMult   float64
+	}{
+		{name: "no event", points: 10, wantPoints: 10, wantMult: 1},
+		{name: "double", points: 10, event: &MultiplierEvent{Multiplier: 2}, wantPoints: 20, wantMult: 2},
+		// 0.29 * 100 в float64 даёт 28.999..., в сотых расчёт точный
+		{name: "hundredths", points: 100, event: &MultiplierEvent{Multiplier: 0.29}, wantPoints: 29, wantMult: 0.29},
+		{name: "half rounds up", points: 3, event: &MultiplierEvent{Multiplier: 1.5}, wantPoints: 5, wantMult: 1.5},
+		{name: "below half rounds down", points: 7, event: &MultiplierEvent{Multiplier: 1.07}, wantPoints: 7, wantMult: 1.07},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reward := NewTaskReward(&Task{RewardPoints: tt.points}, tt.event)
+			if reward.Points != tt.wantPoints || reward.Multiplier != tt.wantMult || reward.BasePoints != tt.points {
+				t.Fatalf("NewTaskReward() = %+v, want points %d, multiplier %v", reward, tt.wantPoints, tt.wantMult)
+			}
+			if (reward.EventID != nil) != (tt.event != nil) {
+				t.Fatalf("NewTaskReward() event id = %v, want set = %t", reward.EventID, tt.event != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PointsLot - партия начисленных баллов. Списания расходуют партии по FIFO,
+// непотраченный остаток сгорает в ExpiresAt. Сумма остатков партий равна
+// положительной части users.points.
+type PointsLot struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	LedgerEntryID *uuid.UUID // запись журнала, создавшая партию; nil для перенесённого баланса
+	Source        LedgerReason
+	Amount        int
+	Remaining     int
+	ExpiresAt     *time.Time // nil — баллы не сгорают
+	CreatedAt     time.Time
+}
+
+// ExpiringPoints - остаток партии, который скоро сгорит
+type ExpiringPoints struct {
+	Points    int
+	ExpiresAt time.Time
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "github.com/google/uuid"
+
+// ReconciliationIssue - вид расхождения, найденного сверкой баллов
+type ReconciliationIssue string
+
+const (
+	ReconciliationIssueBalanceDrift           ReconciliationIssue = "balance_drift"            // users.points не совпадает с ожидаемым балансом
+	ReconciliationIssueLedgerDrift            ReconciliationIssue = "ledger_drift"             // сумма журнала с недостающими проводками не совпадает с ожидаемым балансом
+	ReconciliationIssueMissingCompletionEntry ReconciliationIssue = "missing_completion_entry" // выполнение без начисления в журнале
+	ReconciliationIssueMissingRevocationEntry ReconciliationIssue = "missing_revocation_entry" // отзыв выполнения без списания в журнале
+)
+
+// ReconciliationMismatch - расхождение между балансом и источником истины.
+// Для balance_drift и ledger_drift Expected — ожидаемый баланс, Actual — users.points
+// или сумма журнала с недостающими проводками; для недостающих проводок Expected —
+// сумма, которую нужно провести.
+type ReconciliationMismatch struct {
+	Issue       ReconciliationIssue
+	UserID      uuid.UUID
+	ReferenceID *uuid.UUID // user_tasks.id для недостающих проводок
+	Expected    int
+	Actual      int
+	Fixed       bool
+}
+
+// ReconciliationReport - результат сверки баллов
+type ReconciliationReport struct {
+	Mismatches []ReconciliationMismatch
+	Fix        bool // запрошено исправление
+	FixedUsers int  // пользователей, у которых расхождения исправлены
+}
+
+// UserBalance - закешированный баланс пользователя, сумма его журнала и ожидаемый баланс:
+// награды за неотозванные выполнения плюс остальные, не связанные с задачами проводки
+type UserBalance struct {
+	UserID      uuid.UUID
+	Points      int
+	LedgerTotal int
+	Expected    int
+}
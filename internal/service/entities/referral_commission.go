@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferralCommission - комиссия реферера с начисления пользователя из его сети
+type ReferralCommission struct {
+	ID            uuid.UUID
+	BeneficiaryID uuid.UUID // реферер, получивший комиссию
+	SourceUserID  uuid.UUID // пользователь, чьё начисление стало источником
+	Level         int       // 1 — прямой реферер, 2 — реферер реферера и т.д.
+	SourceEntryID uuid.UUID // запись журнала с исходным начислением
+	SourceReason  LedgerReason
+	SourceAmount  int
+	RateBps       int // ставка в сотых долях процента
+	Amount        int
+	CreatedAt     time.Time
+	ReversedAt    *time.Time // комиссия списана при отзыве исходного начисления
+}
+
+// ReferralAncestor - реферер пользователя на заданном уровне цепочки
+type ReferralAncestor struct {
+	UserID uuid.UUID
+	Level  int
+}
+
+// ReferralTreeNode - участник сети приглашённых пользователя
+type ReferralTreeNode struct {
+	UserID     uuid.UUID
+	Username   string
+	ReferrerID uuid.UUID
+	Level      int // 1 — приглашён напрямую
+	Earned     int // комиссии, полученные корнем сети с начислений участника
+	JoinedAt   time.Time
+}
+
+// ReferralTree - сеть приглашённых пользователя
+type ReferralTree struct {
+	UserID      uuid.UUID
+	Depth       int
+	TotalEarned int
+	Nodes       []ReferralTreeNode // в порядке обхода в ширину
+}
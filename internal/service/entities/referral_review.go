@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignupInfo - данные запроса, с которого зарегистрировался пользователь
+type SignupInfo struct {
+	IP        string
+	UserAgent string
+
+	// реферер из ссылки приглашения: код или ID
+	ReferralCode string
+	ReferrerID   *uuid.UUID
+
+	UTMSource   string
+	UTMCampaign string
+}
+
+// FraudSignal - признак фермы аккаунтов
+type FraudSignal string
+
+const (
+	FraudSignalSharedIP        FraudSignal = "shared_ip"         // IP регистрации совпадает с реферером или другим его приглашённым
+	FraudSignalSharedUserAgent FraudSignal = "shared_user_agent" // User-Agent регистрации совпадает с реферером или другим его приглашённым
+	FraudSignalSignupBurst     FraudSignal = "signup_burst"      // много приглашений реферера за короткое время
+	FraudSignalIdleReferees    FraudSignal = "idle_referees"     // приглашённые реферера не выполняют других заданий
+)
+
+// ReferralFraudStats - данные для оценки приглашения
+type ReferralFraudStats struct {
+	SharedIP        int // пользователи сети реферера с тем же IP регистрации
+	SharedUserAgent int // пользователи сети реферера с тем же User-Agent
+	RecentReferrals int // приглашения реферера в окне всплеска
+	Referees        int // другие приглашённые реферера, достаточно давние для оценки активности
+	IdleReferees    int // из них не выполнили ни одного задания вне реферальной категории
+}
+
+// ReferralReviewStatus - статус проверки приглашения
+type ReferralReviewStatus string
+
+const (
+	ReferralReviewStatusPending  ReferralReviewStatus = "pending"
+	ReferralReviewStatusApproved ReferralReviewStatus = "approved"
+	ReferralReviewStatusRejected ReferralReviewStatus = "rejected"
+)
+
+// ReferralReviewTrigger - момент, в который приглашение признано подозрительным
+type ReferralReviewTrigger string
+
+const (
+	ReferralReviewTriggerReferrerSet   ReferralReviewTrigger = "referrer_set"   // при указании реферера
+	ReferralReviewTriggerTaskCompleted ReferralReviewTrigger = "task_completed" // когда выполнения открыли бонус рефереру
+)
+
+// ReferralReview - подозрительное приглашение в очереди проверки.
+// Пока проверка не одобрена, бонусы за приглашение не начисляются.
+type ReferralReview struct {
+	ID         uuid.UUID
+	RefereeID  uuid.UUID
+	ReferrerID uuid.UUID
+	Trigger    ReferralReviewTrigger
+	Score      int
+	Signals    []FraudSignal
+	Status     ReferralReviewStatus
+	ReviewedBy *uuid.UUID // администратор, принявший решение
+	Note       string
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
+}
+
+// ReferralReviewFilter - фильтр очереди проверки, пустой статус не ограничивает выборку
+type ReferralReviewFilter struct {
+	Status ReferralReviewStatus
+}
+
+// ReferralReviewDecision - решение администратора по приглашению
+type ReferralReviewDecision struct {
+	ReviewID uuid.UUID
+	AdminID  uuid.UUID
+	Status   ReferralReviewStatus // approved или rejected
+	Note     string
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferralReward - бонусы за приглашение. Размеры и условие фиксируются
+// в момент указания реферера, поэтому смена настроек не меняет уже выданные приглашения.
+type ReferralReward struct {
+	RefereeID          uuid.UUID
+	ReferrerID         uuid.UUID
+	RefereeBonus       int
+	ReferrerBonus      int
+	ReferrerAfterTasks int        // сколько заданий должен выполнить приглашённый до бонуса рефереру
+	ReferrerPaidAt     *time.Time // nil, пока бонус рефереру не начислен
+	CreatedAt          time.Time
+}
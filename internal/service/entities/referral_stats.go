@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referee - пользователь, напрямую приглашённый реферером
+type Referee struct {
+	UserID         uuid.UUID
+	Username       string
+	ReferredAt     time.Time
+	CompletedTasks int // действующие выполнения заданий
+	Earned         int // бонус и комиссии, полученные реферером за этого пользователя
+}
+
+// IsActive сообщает, выполнил ли приглашённый хотя бы одно задание
+func (r Referee) IsActive() bool {
+	return r.CompletedTasks > 0
+}
+
+// RefereeFilter - параметры страницы приглашённых
+type RefereeFilter struct {
+	After *RefereePosition
+	Limit int
+}
+
+// RefereePosition - позиция приглашённого в списке, новые приглашённые идут первыми
+type RefereePosition struct {
+	ReferredAt time.Time
+	ID         uuid.UUID
+}
+
+// RefereePage - страница приглашённых
+type RefereePage struct {
+	Referees []Referee
+	Next     *RefereePosition // nil, если приглашённых больше нет
+}
+
+// ReferralSummary - сводка по прямым приглашённым реферера
+type ReferralSummary struct {
+	Total       int
+	Active      int // выполнили хотя бы одно задание
+	LastDay     int // приглашены за последние сутки
+	LastWeek    int // за последние 7 дней
+	LastMonth   int // за последние 30 дней
+	TotalEarned int // бонусы за приглашения и комиссии со всей сети
+}
+
+// ReferralStats - статистика приглашений пользователя
+type ReferralStats struct {
+	UserID uuid.UUID
+	ReferralSummary
+	RefereePage
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reward - товар магазина наград, покупается за баллы
+type Reward struct {
+	ID           uuid.UUID
+	Code         string
+	Title        string
+	Description  string
+	Price        int
+	Stock        *int // остаток, nil — без ограничения
+	PerUserLimit *int // сколько раз один пользователь может получить награду, nil — без ограничения
+	Active       bool
+	CreatedAt    time.Time
+}
+
+// RedemptionStatus - статус заказа награды
+type RedemptionStatus string
+
+const (
+	RedemptionStatusPending   RedemptionStatus = "pending"
+	RedemptionStatusFulfilled RedemptionStatus = "fulfilled"
+	RedemptionStatusCancelled RedemptionStatus = "cancelled"
+)
+
+// Redemption - заказ награды пользователем. Баллы списываются при создании
+// и возвращаются при отмене.
+type Redemption struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	RewardID    uuid.UUID
+	RewardCode  string
+	Price       int // списанная сумма, фиксируется на момент заказа
+	Status      RedemptionStatus
+	ProcessedBy *uuid.UUID // администратор, выдавший или отменивший заказ
+	Note        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RedemptionFilter - фильтр списка заказов, пустые поля не ограничивают выборку
+type RedemptionFilter struct {
+	UserID *uuid.UUID
+	Status RedemptionStatus
+}
+
+// RedemptionDecision - решение администратора по заказу
+type RedemptionDecision struct {
+	RedemptionID uuid.UUID
+	AdminID      uuid.UUID
+	Status       RedemptionStatus // fulfilled или cancelled
+	Note         string
+}
@@ -8,9 +8,45 @@ import (
 
 // Task - задача, которую можно выполнить для получения очков
 type Task struct {
-	ID           uuid.UUID
-	Code         string
-	Description  string
-	RewardPoints int
-	CreatedAt    time.Time
+	ID            uuid.UUID
+	Code          string
+	Title         string // локализованный заголовок, по умолчанию — код задачи
+	Description   string // локализованное описание
+	RewardPoints  int
+	TargetCount   int           // сколько шагов нужно для выполнения, 1 — обычная задача
+	ProgressEvent ProgressEvent // событие, которое двигает прогресс; пусто — прогресс приходит через API
+	Category      string        // код категории, может быть пустым
+	Tags          []string      // коды тегов
+	CompletionCap *int          // глобальный лимит выполнений, nil — без лимита
+	Completions   int           // число действующих выполнений
+	CreatedAt     time.Time
+}
+
+// IsProgressBased сообщает, выполняется ли задача накоплением прогресса
+func (t *Task) IsProgressBased() bool {
+	return t.TargetCount > 1
+}
+
+// RemainingSlots возвращает число оставшихся выполнений или nil для задачи без лимита
+func (t *Task) RemainingSlots() *int {
+	if t.CompletionCap == nil {
+		return nil
+	}
+	remaining := max(*t.CompletionCap-t.Completions, 0)
+	return &remaining
+}
+
+// TaskFilter - фильтр каталога задач, пустые поля не ограничивают выборку
+type TaskFilter struct {
+	Category string
+	Tag      string
+}
+
+// TaskTranslation - перевод заголовка и описания задачи на конкретный язык
+type TaskTranslation struct {
+	TaskID      uuid.UUID
+	Locale      string
+	Title       string
+	Description string
+	UpdatedAt   time.Time
 }
@@ -0,0 +1,49 @@
+package entities
+
+import "github.com/google/uuid"
+
+// TaskImportStatus - итог обработки строки импорта
+type TaskImportStatus string
+
+const (
+	// TaskImportStatusValid — строка прошла проверку (в режиме dry-run)
+	TaskImportStatusValid TaskImportStatus = "valid"
+	// TaskImportStatusCompleted — задание засчитано
+	TaskImportStatusCompleted TaskImportStatus = "completed"
+	// TaskImportStatusSkipped — задание уже выполнено или строка повторяется в файле
+	TaskImportStatusSkipped TaskImportStatus = "skipped"
+	// TaskImportStatusFailed — строка не прошла проверку
+	TaskImportStatusFailed TaskImportStatus = "failed"
+)
+
+// TaskImportRow - строка файла импорта выполнений.
+// Пользователь задаётся либо UserID, либо Username.
+type TaskImportRow struct {
+	Line     int
+	UserID   *uuid.UUID
+	Username string
+	TaskCode string
+	Metadata map[string]string
+}
+
+// TaskImportRowResult - результат обработки одной строки импорта
+type TaskImportRowResult struct {
+	Line       int
+	Status     TaskImportStatus
+	UserID     *uuid.UUID
+	TaskID     *uuid.UUID
+	UserTaskID *uuid.UUID
+	Error      string
+}
+
+// TaskImportReport - отчёт об импорте выполнений
+type TaskImportReport struct {
+	DryRun    bool
+	Applied   bool
+	Total     int
+	Valid     int
+	Completed int
+	Skipped   int
+	Failed    int
+	Rows      []TaskImportRowResult
+}
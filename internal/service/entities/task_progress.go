@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProgressEvent - внутреннее событие, которое увеличивает прогресс задач
+type ProgressEvent string
+
+const (
+	// ProgressEventReferralJoined - новый реферал указал пользователя своим реферером
+	ProgressEventReferralJoined ProgressEvent = "referral_joined"
+)
+
+// TaskProgress - прогресс пользователя по задаче со счётчиком
+type TaskProgress struct {
+	TaskID      uuid.UUID
+	Code        string
+	Title       string
+	Description string
+	Progress    int
+	Target      int
+	Completed   bool
+	UpdatedAt   time.Time
+}
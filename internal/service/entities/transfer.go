@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PointsTransfer - перевод баллов от одного пользователя другому
+type PointsTransfer struct {
+	ID          uuid.UUID
+	SenderID    uuid.UUID
+	RecipientID uuid.UUID
+	Amount      int
+	Note        string
+	CreatedAt   time.Time
+}
@@ -6,12 +6,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// Role - роль пользователя в системе
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 // User - пользователь системы
 type User struct {
-	ID         uuid.UUID
-	Username   string
-	Password   string
-	Points     int
-	ReferrerID *uuid.UUID // может быть nil
-	CreatedAt  time.Time
+	ID           uuid.UUID
+	Username     string
+	Password     string
+	Points       int
+	ReferrerID   *uuid.UUID // может быть nil
+	ReferralCode string     // код приглашения, по которому пользователя указывают реферером
+	Role         Role
+	CreatedAt    time.Time
+}
+
+// IsAdmin сообщает, может ли пользователь вызывать админские методы
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
@@ -9,16 +9,30 @@ import (
 type CompletedTask struct {
 	ID          uuid.UUID
 	Code        string
+	Title       string
 	Description string
-	Points      int
+	Points      int // итоговое начисление с учётом множителя
+	BasePoints  int
+	Multiplier  float64
+	Boost       *PointsBoost // nil, если множитель не применялся
 	Metadata    map[string]string
 	CompletedAt time.Time
 }
 
+// PointsBoost - событие-множитель, применённое к выполненной задаче
+type PointsBoost struct {
+	EventID   *uuid.UUID // nil, если событие удалено
+	EventName string
+}
+
 type UserStatus struct {
-	ID             uuid.UUID
-	Username       string
-	Points         int
-	ReferrerID     *uuid.UUID
-	CompletedTasks []CompletedTask
+	ID              uuid.UUID
+	Username        string
+	Points          int
+	ReferrerID      *uuid.UUID
+	ReferralCode    string
+	CompletedTasks  []CompletedTask
+	TasksInProgress []TaskProgress
+	Badges          []UserBadge
+	ExpiringSoon    []ExpiringPoints // остатки партий, сгорающие в ближайшее время, раньше сгорающие первыми
 }
@@ -6,10 +6,31 @@ import (
 	"github.com/google/uuid"
 )
 
-// UserTask - выполнение задачи конкретным пользователем
+// UserTask - выполнение задачи конкретным пользователем.
+// Отозванное выполнение остаётся в базе для аудита.
 type UserTask struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	TaskID      uuid.UUID
-	CompletedAt time.Time
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	TaskID          uuid.UUID
+	Points          int
+	CompletedAt     time.Time
+	RevokedAt       *time.Time
+	RevokedBy       *uuid.UUID
+	RevokeReason    string
+	AllowRecomplete bool // можно ли выполнить задачу повторно после отзыва
+}
+
+// RevokedTaskCompletion - итог отзыва выполнения
+type RevokedTaskCompletion struct {
+	UserTask
+	BalanceAfter        int // баланс после списания; отрицательный, если баллы уже потрачены
+	CommissionsReversed int // сумма комиссий, списанных с рефереров
+}
+
+// TaskRevocation - запрос администратора на отзыв выполнения
+type TaskRevocation struct {
+	UserTaskID      uuid.UUID
+	AdminID         uuid.UUID
+	Reason          string
+	AllowRecomplete bool
 }
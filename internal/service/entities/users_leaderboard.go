@@ -1,11 +1,58 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardPeriod - период, за который считается рейтинг
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodAll   LeaderboardPeriod = "all"   // весь баланс пользователя
+	LeaderboardPeriodDay   LeaderboardPeriod = "day"   // заработано с начала суток
+	LeaderboardPeriodWeek  LeaderboardPeriod = "week"  // с начала недели (понедельник)
+	LeaderboardPeriodMonth LeaderboardPeriod = "month" // с начала месяца
+)
+
+// IsHourAlignedLocation сообщает, смещена ли зона от UTC на целое число часов во всех
+// интервалах между from и to. Рейтинги за период собираются из часовых корзин, и в зоне
+// с дробным смещением сутки начинались бы посреди корзины.
+func IsHourAlignedLocation(loc *time.Location, from, to time.Time) bool {
+	for t := from.In(loc); t.Before(to); {
+		if _, offset := t.Zone(); offset%3600 != 0 {
+			return false
+		}
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			break
+		}
+		t = end
+	}
+	return true
+}
 
 type UserLeader struct {
 	ID       uuid.UUID
 	Username string
-	Points   int
+	Points   int // баланс или заработок за период
+	Rank     int // место; при равных баллах место общее: 1, 2, 2, 4
 }
 
 type UsersLeaderboard []UserLeader
+
+// LeaderboardPage - страница рейтинга и место запросившего пользователя
+type LeaderboardPage struct {
+	Period LeaderboardPeriod
+	Total  int // участников рейтинга
+	Users  UsersLeaderboard
+	Me     *UserLeader // nil, если пользователь не участвует в рейтинге за период
+}
+
+// LeaderboardAround - пользователи рейтинга по балансу вокруг заданного пользователя
+type LeaderboardAround struct {
+	UserID uuid.UUID
+	Radius int
+	Users  UsersLeaderboard // стоящие выше, сам пользователь и стоящие ниже, в порядке рейтинга
+}
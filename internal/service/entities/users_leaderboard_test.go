@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsHourAlignedLocation(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(2, 0, 0)
+
+	tests := []struct {
+		zone string
+		want bool
+	}{
+		{zone: "UTC", want: true},
+		{zone: "Europe/Moscow", want: true},
+		{zone: "America/New_York", want: true}, // переход на летнее время на целый час
+		{zone: "Asia/Kolkata", want: false},
+		{zone: "Asia/Kathmandu", want: false},
+		{zone: "Australia/Lord_Howe", want: false}, // летнее время сдвигает на полчаса
+	}
+
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Fatalf("LoadLocation(%s) error = %v", tt.zone, err)
+		}
+		if got := IsHourAlignedLocation(loc, from, to); got != tt.want {
+			t.Errorf("IsHourAlignedLocation(%s) = %t, want %t", tt.zone, got, tt.want)
+		}
+	}
+}
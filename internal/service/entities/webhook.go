@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PartnerTaskCompletion - выполнение задачи, о котором сообщил партнёр через вебхук
+type PartnerTaskCompletion struct {
+	Partner        string
+	ExternalUserID string // идентификатор пользователя в системе партнёра
+	TaskCode       string
+	Nonce          string // одноразовый идентификатор доставки
+	Payload        []byte // сырое тело запроса, сохраняется вместе с выполнением
+}
+
+// PartnerUserLink - связь пользователя партнёра с нашим пользователем
+type PartnerUserLink struct {
+	Partner        string
+	ExternalUserID string
+	UserID         uuid.UUID
+	CreatedAt      time.Time
+}
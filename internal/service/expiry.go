@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+// expiryBatchSize — сколько пользователей обрабатывает один проход задачи сгорания
+const expiryBatchSize = 100
+
+// trackPointsLots отражает проводку журнала в партиях баллов: начисление создаёт партию,
+// списание расходует партии по FIFO. Срок жизни партии отсчитывается от времени проводки.
+// Должна вызываться в той же транзакции, что и проводка.
+func (s *Service) trackPointsLots(ctx context.Context, entry *entities.LedgerEntry) error {
+	if entry.Amount < 0 {
+		return s.storage.ConsumePointsLots(ctx, entry.UserID, -entry.Amount)
+	}
+
+	// при отрицательном балансе начисление сначала гасит долг, партия получает только остаток
+	remaining := min(entry.Amount, entry.BalanceAfter)
+	if remaining <= 0 {
+		return nil
+	}
+
+	lot := entities.PointsLot{
+		UserID:        entry.UserID,
+		LedgerEntryID: &entry.ID,
+		Source:        entry.Reason,
+		Amount:        remaining,
+		Remaining:     remaining,
+	}
+	if days, ok := s.expiryDays[entry.Reason]; ok {
+		expiresAt := entry.CreatedAt.AddDate(0, 0, days)
+		lot.ExpiresAt = &expiresAt
+	}
+
+	_, err := s.storage.CreatePointsLot(ctx, lot)
+	return err
+}
+
+// ExpirePoints списывает сгоревшие остатки партий. Каждый пользователь обрабатывается
+// в своей транзакции: сначала блокируется его строка, затем партии — в том же порядке,
+// что и при обычных проводках. Возвращает число списанных баллов.
+func (s *Service) ExpirePoints(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var expired int
+	for {
+		userIDs, err := s.storage.GetUsersWithExpiredLots(ctx, now, expiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, userID := range userIDs {
+			var userExpired int
+			err := s.txManager.Do(ctx, func(ctx context.Context) error {
+				if _, err := s.storage.LockUsers(ctx, userID); err != nil {
+					return err
+				}
+
+				lots, err := s.storage.ExpireUserLots(ctx, userID, now)
+				if err != nil {
+					return err
+				}
+
+				for _, lot := range lots {
+					// партия уже обнулена, поэтому проводка идёт мимо trackPointsLots
+					if _, err := s.storage.AddLedgerEntry(ctx, entities.LedgerEntry{
+						UserID:      userID,
+						Amount:      -lot.Remaining,
+						Reason:      entities.LedgerReasonPointsExpired,
+						ReferenceID: &lot.ID,
+					}); err != nil {
+						return err
+					}
+					userExpired += lot.Remaining
+				}
+
+				return nil
+			})
+			if err != nil {
+				return expired, err
+			}
+			expired += userExpired
+		}
+
+		if len(userIDs) < expiryBatchSize {
+			return expired, nil
+		}
+	}
+}
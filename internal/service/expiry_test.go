@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+func TestSpendingConsumesOldestLotsFirst(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithPointsExpiry(map[string]int{"task_completed": 30}, 0))
+	userID := st.addUser(nil)
+
+	for _, amount := range []int{40, 30, 20} {
+		if err := s.postLedgerEntry(ctx, userID, amount, entities.LedgerReasonTaskCompleted, nil); err != nil {
+			t.Fatalf("postLedgerEntry() error = %v", err)
+		}
+	}
+
+	if err := s.spendPoints(ctx, userID, 55, entities.LedgerReasonRedemption, nil); err != nil {
+		t.Fatalf("spendPoints() error = %v", err)
+	}
+
+	lots := st.userLots(userID)
+	want := []int{0, 15, 20}
+	for i, lot := range lots {
+		if lot.Remaining != want[i] {
+			t.Fatalf("lot %d remaining = %d, want %d", i, lot.Remaining, want[i])
+		}
+	}
+}
+
+func TestExpirePointsWritesOffOnlyRemainders(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithPointsExpiry(map[string]int{"task_completed": 30}, 0))
+	userID := st.addUser(nil)
+
+	if err := s.postLedgerEntry(ctx, userID, 40, entities.LedgerReasonTaskCompleted, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	// бонусы без срока не сгорают
+	if err := s.postLedgerEntry(ctx, userID, 25, entities.LedgerReasonRefereeBonus, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if err := s.spendPoints(ctx, userID, 10, entities.LedgerReasonRedemption, nil); err != nil {
+		t.Fatalf("spendPoints() error = %v", err)
+	}
+
+	lots := st.userLots(userID)
+	if lots[0].ExpiresAt == nil || lots[1].ExpiresAt != nil {
+		t.Fatalf("lot expiry = %v, %v; want set, nil", lots[0].ExpiresAt, lots[1].ExpiresAt)
+	}
+
+	// ещё не истёкшие партии не трогаются
+	expired, err := s.ExpirePoints(ctx)
+	if err != nil {
+		t.Fatalf("ExpirePoints() error = %v", err)
+	}
+	if expired != 0 {
+		t.Fatalf("ExpirePoints() before expiry = %d, want 0", expired)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	st.lots[0].ExpiresAt = &past
+
+	expired, err = s.ExpirePoints(ctx)
+	if err != nil {
+		t.Fatalf("ExpirePoints() error = %v", err)
+	}
+	if expired != 30 {
+		t.Fatalf("ExpirePoints() = %d, want 30", expired)
+	}
+	if got := st.balance(t, userID); got != 25 {
+		t.Fatalf("balance = %d, want 25", got)
+	}
+	if got := st.entries(userID, entities.LedgerReasonPointsExpired); len(got) != 1 || got[0].Amount != -30 {
+		t.Fatalf("points_expired entries = %+v, want one entry of -30", got)
+	}
+
+	// повторный запуск ничего не списывает
+	if expired, err = s.ExpirePoints(ctx); err != nil || expired != 0 {
+		t.Fatalf("ExpirePoints() again = %d, %v; want 0, nil", expired, err)
+	}
+}
+
+func TestTrackPointsLotsExpiresFromEntryTime(t *testing.T) {
+	s, st := newTestService(WithPointsExpiry(map[string]int{"task_completed": 30}, 0))
+	userID := st.addUser(nil)
+
+	createdAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	entry := &entities.LedgerEntry{
+		UserID:       userID,
+		Amount:       10,
+		Reason:       entities.LedgerReasonTaskCompleted,
+		BalanceAfter: 10,
+		CreatedAt:    createdAt,
+	}
+	if err := s.trackPointsLots(context.Background(), entry); err != nil {
+		t.Fatalf("trackPointsLots() error = %v", err)
+	}
+
+	lots := st.userLots(userID)
+	if want := createdAt.AddDate(0, 0, 30); lots[0].ExpiresAt == nil || !lots[0].ExpiresAt.Equal(want) {
+		t.Fatalf("lot expires at %v, want %v", lots[0].ExpiresAt, want)
+	}
+}
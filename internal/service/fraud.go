@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// Веса признаков фермы аккаунтов в оценке приглашения
+const (
+	fraudWeightSharedIP        = 40
+	fraudWeightSharedUserAgent = 20
+	fraudWeightSignupBurst     = 30
+	fraudWeightIdleReferees    = 30
+)
+
+const (
+	// fraudIdleRefereeAge — приглашённые моложе этого срока не учитываются в доле неактивных
+	fraudIdleRefereeAge = 7 * 24 * time.Hour
+	// fraudIdleMinReferees — с меньшим числом приглашённых доля неактивных не оценивается
+	fraudIdleMinReferees = 5
+)
+
+// scoreReferral оценивает, насколько приглашение похоже на ферму аккаунтов
+func (s *Service) scoreReferral(ctx context.Context, refereeID, referrerID uuid.UUID) (int, []entities.FraudSignal, error) {
+	now := time.Now()
+	stats, err := s.storage.GetReferralFraudStats(ctx, refereeID, referrerID, now.Add(-s.fraudBurstWindow), now.Add(-fraudIdleRefereeAge))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		score   int
+		signals []entities.FraudSignal
+	)
+	if stats.SharedIP > 0 {
+		score += fraudWeightSharedIP
+		signals = append(signals, entities.FraudSignalSharedIP)
+	}
+	if stats.SharedUserAgent > 0 {
+		score += fraudWeightSharedUserAgent
+		signals = append(signals, entities.FraudSignalSharedUserAgent)
+	}
+	if stats.RecentReferrals > s.fraudBurstSignups {
+		score += fraudWeightSignupBurst
+		signals = append(signals, entities.FraudSignalSignupBurst)
+	}
+	if stats.Referees >= fraudIdleMinReferees && float64(stats.IdleReferees) >= s.fraudIdleRatio*float64(stats.Referees) {
+		score += fraudWeightIdleReferees
+		signals = append(signals, entities.FraudSignalIdleReferees)
+	}
+
+	return score, signals, nil
+}
+
+// holdSuspiciousReferral оценивает приглашение и при оценке не ниже порога задерживает
+// его бонусы и ставит приглашение в очередь проверки. Одобренное администратором
+// приглашение повторно не оценивается. Должна вызываться внутри транзакции.
+func (s *Service) holdSuspiciousReferral(ctx context.Context, refereeID, referrerID uuid.UUID, trigger entities.ReferralReviewTrigger) (bool, error) {
+	if s.fraudScoreThreshold == 0 {
+		return false, nil
+	}
+
+	review, err := s.storage.GetLatestReferralReview(ctx, refereeID)
+	if err != nil {
+		return false, err
+	}
+	if review != nil {
+		return review.Status != entities.ReferralReviewStatusApproved, nil
+	}
+
+	score, signals, err := s.scoreReferral(ctx, refereeID, referrerID)
+	if err != nil {
+		return false, err
+	}
+	if score < s.fraudScoreThreshold {
+		return false, nil
+	}
+
+	_, err = s.storage.CreateReferralReview(ctx, entities.ReferralReview{
+		RefereeID:  refereeID,
+		ReferrerID: referrerID,
+		Trigger:    trigger,
+		Score:      score,
+		Signals:    signals,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.storage.SetReferralRewardHeld(ctx, refereeID, true)
+}
+
+// isReferralHeld сообщает, ждёт ли приглашение пользователя проверки или отклонено
+func (s *Service) isReferralHeld(ctx context.Context, refereeID uuid.UUID) (bool, error) {
+	review, err := s.storage.GetLatestReferralReview(ctx, refereeID)
+	if err != nil || review == nil {
+		return false, err
+	}
+
+	return review.Status != entities.ReferralReviewStatusApproved, nil
+}
+
+// ListReferralReviews возвращает очередь проверки приглашений
+func (s *Service) ListReferralReviews(ctx context.Context, filter entities.ReferralReviewFilter) ([]*entities.ReferralReview, error) {
+	return s.storage.ListReferralReviews(ctx, filter)
+}
+
+// DecideReferralReview одобряет или отклоняет подозрительное приглашение.
+// При одобрении задержанные бонусы и комиссии начисляются, а приглашение, задержанное
+// при указании реферера, засчитывается рефереру. При отклонении всё остаётся задержанным.
+func (s *Service) DecideReferralReview(ctx context.Context, decision entities.ReferralReviewDecision) (*entities.ReferralReview, error) {
+	var review *entities.ReferralReview
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		review, err = s.storage.DecideReferralReview(ctx, decision)
+		if err != nil {
+			return err
+		}
+
+		if review.Status != entities.ReferralReviewStatusApproved {
+			return nil
+		}
+
+		// прогресс реферера может принести комиссии и его цепочке, блокируем обе
+		if err := s.lockReferralChains(ctx, review.RefereeID, review.ReferrerID); err != nil {
+			return err
+		}
+
+		if err := s.storage.SetReferralRewardHeld(ctx, review.RefereeID, false); err != nil {
+			return err
+		}
+		if err := s.payRefereeReward(ctx, review.RefereeID); err != nil {
+			return err
+		}
+		if err := s.claimReferrerReward(ctx, review.RefereeID); err != nil {
+			return err
+		}
+
+		// приглашение, задержанное при выполнении заданий, рефереру уже засчитано
+		if review.Trigger == entities.ReferralReviewTriggerReferrerSet {
+			if err := s.handleProgressEvent(ctx, review.ReferrerID, entities.ProgressEventReferralJoined); err != nil {
+				return err
+			}
+		}
+
+		return s.payHeldReferralCommissions(ctx, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestHeldReferralDefersProgressAndCommissionsUntilApproved(t *testing.T) {
+	ctx := context.Background()
+	// бонусы за приглашение выключены, но приглашение всё равно оценивается
+	s, st := newTestService(
+		WithReferralCommissions([]float64{10}),
+		WithFraudScoring(fraudWeightSharedIP, time.Hour, 5, 0.8),
+	)
+	st.fraudStats = entities.ReferralFraudStats{SharedIP: 1}
+
+	inviteTaskID := st.addTask(250, 5, nil)
+	inviteTask := st.tasks[inviteTaskID]
+	inviteTask.ProgressEvent = entities.ProgressEventReferralJoined
+	st.tasks[inviteTaskID] = inviteTask
+	taskID := st.addTask(100, 1, nil)
+
+	referrerID := st.addUser(nil)
+	refereeID := st.addUser(nil)
+
+	if err := s.InputReferrer(ctx, refereeID, referrerID); err != nil {
+		t.Fatalf("InputReferrer() error = %v", err)
+	}
+	if len(st.reviews) != 1 || st.reviews[0].Trigger != entities.ReferralReviewTriggerReferrerSet {
+		t.Fatalf("reviews = %+v, want one held at referrer set", st.reviews)
+	}
+
+	// до одобрения приглашение не двигает прогресс реферера и не приносит ему комиссий
+	if err := s.CompleteTask(ctx, refereeID, taskID, nil); err != nil {
+		t.Fatalf("CompleteTask() error = %v", err)
+	}
+	if got := st.progress[[2]uuid.UUID{referrerID, inviteTaskID}]; got != 0 {
+		t.Fatalf("referrer progress before approval = %d, want 0", got)
+	}
+	if got := st.balance(t, referrerID); got != 0 {
+		t.Fatalf("referrer balance before approval = %d, want 0", got)
+	}
+
+	review, err := s.DecideReferralReview(ctx, entities.ReferralReviewDecision{
+		ReviewID: st.reviews[0].ID,
+		Status:   entities.ReferralReviewStatusApproved,
+	})
+	if err != nil {
+		t.Fatalf("DecideReferralReview() error = %v", err)
+	}
+	if review.Status != entities.ReferralReviewStatusApproved {
+		t.Fatalf("review status = %s, want approved", review.Status)
+	}
+
+	if got := st.progress[[2]uuid.UUID{referrerID, inviteTaskID}]; got != 1 {
+		t.Fatalf("referrer progress after approval = %d, want 1", got)
+	}
+	if got := st.balance(t, referrerID); got != 10 {
+		t.Fatalf("referrer balance after approval = %d, want 10", got)
+	}
+	if len(st.commissions) != 1 || st.commissions[0].SourceUserID != refereeID {
+		t.Fatalf("commissions = %+v, want one from the referee", st.commissions)
+	}
+
+	// новые начисления одобренного приглашения приносят комиссии сразу
+	otherTaskID := st.addTask(50, 1, nil)
+	if err := s.CompleteTask(ctx, refereeID, otherTaskID, nil); err != nil {
+		t.Fatalf("CompleteTask() error = %v", err)
+	}
+	if got := st.balance(t, referrerID); got != 15 {
+		t.Fatalf("referrer balance = %d, want 15", got)
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// leaderboardTotalTTL — сколько переиспользуется число участников рейтинга по балансу
+const leaderboardTotalTTL = 30 * time.Second
+
+// cachedCount — счётчик, значение которого переиспользуется до expiresAt
+type cachedCount struct {
+	mu        sync.Mutex
+	value     int
+	expiresAt time.Time
+}
+
+// earnedReasons — изменения баланса, которые считаются заработком в рейтингах за период.
+// Переводы не учитываются, иначе баллы можно было бы собрать на одном аккаунте,
+// а покупки и сгорание — это трата, а не потеря заработанного.
+var earnedReasons = map[entities.LedgerReason]bool{
+	entities.LedgerReasonTaskCompleted:              true,
+	entities.LedgerReasonTaskRevoked:                true,
+	entities.LedgerReasonRefereeBonus:               true,
+	entities.LedgerReasonReferrerBonus:              true,
+	entities.LedgerReasonReferralCommission:         true,
+	entities.LedgerReasonReferralCommissionReversed: true,
+	entities.LedgerReasonAdminAdjustment:            true,
+}
+
+// trackEarnedPoints учитывает проводку в часовой корзине заработка пользователя.
+// Должна вызываться в транзакции проводки.
+func (s *Service) trackEarnedPoints(ctx context.Context, entry *entities.LedgerEntry) error {
+	if !earnedReasons[entry.Reason] {
+		return nil
+	}
+	return s.storage.AddEarnedPoints(ctx, entry.UserID, entry.CreatedAt, entry.Amount)
+}
+
+// GetUsersLeaderboard возвращает страницу рейтинга с местами, число участников
+// и место пользователя userID, даже если он не попал на страницу.
+// За всё время рейтинг строится по балансу, за период — по баллам,
+// заработанным с начала текущих суток, недели или месяца; такой рейтинг читается одним запросом,
+// чтобы не агрегировать часовые корзины трижды.
+func (s *Service) GetUsersLeaderboard(ctx context.Context, userID uuid.UUID, period entities.LeaderboardPeriod, limit, offset int) (*entities.LeaderboardPage, error) {
+	if period != entities.LeaderboardPeriodAll {
+		page, err := s.storage.GetPeriodLeaderboardPage(ctx, userID, s.periodStart(period, time.Now()), limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		page.Period = period
+		return page, nil
+	}
+
+	page := &entities.LeaderboardPage{Period: period}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+
+	// страница, число участников и место пользователя независимы, читаем параллельно
+	g.Go(func() error {
+		var err error
+		page.Users, err = s.storage.GetUsersLeaderboard(groupCtx, limit, offset)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		page.Total, err = s.countUsersLeaderboard(groupCtx)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		page.Me, err = s.storage.GetUserLeaderboardPosition(groupCtx, userID)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// countUsersLeaderboard возвращает число участников рейтинга по балансу. Подсчёт читает
+// всю таблицу пользователей, поэтому значение переиспользуется leaderboardTotalTTL:
+// итог может отставать от регистраций на это время. Пока значение обновляется,
+// остальные запросы ждут его, а не считают параллельно.
+func (s *Service) countUsersLeaderboard(ctx context.Context) (int, error) {
+	s.leaderboardTotal.mu.Lock()
+	defer s.leaderboardTotal.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(s.leaderboardTotal.expiresAt) {
+		return s.leaderboardTotal.value, nil
+	}
+
+	total, err := s.storage.CountUsersLeaderboard(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.leaderboardTotal.value = total
+	s.leaderboardTotal.expiresAt = now.Add(leaderboardTotalTTL)
+	return total, nil
+}
+
+// GetLeaderboardAround возвращает до radius пользователей выше и ниже userID
+// в рейтинге по балансу вместе с ним самим. Соседи выбираются по ключу (points, id),
+// места считаются от места пользователя, а не сдвигом по всему рейтингу.
+func (s *Service) GetLeaderboardAround(ctx context.Context, userID uuid.UUID, radius int) (*entities.LeaderboardAround, error) {
+	target, err := s.storage.GetUserLeaderboardPosition(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	var (
+		above, below entities.UsersLeaderboard
+		tied         int
+	)
+
+	g, groupCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		above, err = s.storage.GetLeaderboardAbove(groupCtx, *target, radius)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		below, err = s.storage.GetLeaderboardBelow(groupCtx, *target, radius)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		tied, err = s.storage.CountTiedAhead(groupCtx, userID, target.Points)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(above)
+	users := make(entities.UsersLeaderboard, 0, len(above)+1+len(below))
+	users = append(users, above...)
+	users = append(users, *target)
+	users = append(users, below...)
+
+	// позиция первой строки: место пользователя плюс стоящие выше с теми же баллами
+	first := target.Rank + tied - len(above)
+
+	for i := range users {
+		switch {
+		case users[i].ID == target.ID:
+		case users[i].Points == target.Points:
+			users[i].Rank = target.Rank
+		case i > 0 && users[i].Points == users[i-1].Points:
+			users[i].Rank = users[i-1].Rank
+		case i > 0:
+			users[i].Rank = first + i
+		default:
+			// группа с равными баллами может начинаться выше окрестности
+			users[i].Rank, err = s.storage.GetLeaderboardRank(ctx, users[i].Points)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &entities.LeaderboardAround{
+		UserID: userID,
+		Radius: radius,
+		Users:  users,
+	}, nil
+}
+
+// periodStart возвращает начало текущего периода рейтинга в зоне рейтинга.
+// Результат переводится в локальную зону сервера, в которой хранятся TIMESTAMP-колонки.
+func (s *Service) periodStart(period entities.LeaderboardPeriod, now time.Time) time.Time {
+	now = now.In(s.leaderboardLocation)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.leaderboardLocation)
+
+	switch period {
+	case entities.LeaderboardPeriodWeek:
+		// неделя начинается с понедельника
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	case entities.LeaderboardPeriodMonth:
+		start = start.AddDate(0, 0, 1-start.Day())
+	}
+
+	return start.Local()
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestGetLeaderboardAround(t *testing.T) {
+	s, st := newTestService()
+
+	// id растут вместе с индексом, поэтому порядок users совпадает с порядком рейтинга
+	points := []int{100, 80, 80, 80, 50, 50, 10}
+	users := make([]uuid.UUID, len(points))
+	for i := range users {
+		users[i] = st.addUser(nil)
+	}
+	slices.SortFunc(users, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	for i, id := range users {
+		u := st.users[id]
+		u.Points = points[i]
+		st.users[id] = u
+	}
+
+	tests := []struct {
+		name   string
+		user   int
+		radius int
+		want   []int // индексы в users
+		ranks  []int
+	}{
+		{name: "last of tie", user: 3, radius: 2, want: []int{1, 2, 3, 4, 5}, ranks: []int{2, 2, 2, 5, 5}},
+		{name: "near top", user: 1, radius: 2, want: []int{0, 1, 2, 3}, ranks: []int{1, 2, 2, 2}},
+		{name: "bottom", user: 6, radius: 2, want: []int{4, 5, 6}, ranks: []int{5, 5, 7}},
+		{name: "tie starts above slice", user: 4, radius: 1, want: []int{3, 4, 5}, ranks: []int{2, 5, 5}},
+		{name: "inside tie", user: 2, radius: 1, want: []int{1, 2, 3}, ranks: []int{2, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			around, err := s.GetLeaderboardAround(context.Background(), users[tt.user], tt.radius)
+			if err != nil {
+				t.Fatalf("GetLeaderboardAround() error = %v", err)
+			}
+			if len(around.Users) != len(tt.want) {
+				t.Fatalf("users = %+v, want %d rows", around.Users, len(tt.want))
+			}
+			for i, l := range around.Users {
+				if l.ID != users[tt.want[i]] || l.Rank != tt.ranks[i] {
+					t.Fatalf("row %d = (%s, rank %d), want (%s, rank %d)", i, l.ID, l.Rank, users[tt.want[i]], tt.ranks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetLeaderboardAroundUnknownUser(t *testing.T) {
+	s, _ := newTestService()
+
+	if _, err := s.GetLeaderboardAround(context.Background(), uuid.New(), 2); !errors.Is(err, entities.ErrUserNotFound) {
+		t.Fatalf("GetLeaderboardAround() error = %v, want %v", err, entities.ErrUserNotFound)
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	s, _ := newTestService(WithLeaderboardLocation(msk))
+
+	// в UTC ещё воскресенье, в зоне рейтинга уже понедельник
+	mondayNight := time.Date(2026, time.October, 18, 22, 30, 0, 0, time.UTC)
+	sunday := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period entities.LeaderboardPeriod
+		now    time.Time
+		want   time.Time
+	}{
+		{name: "day in location", period: entities.LeaderboardPeriodDay, now: mondayNight, want: time.Date(2026, time.October, 19, 0, 0, 0, 0, msk)},
+		{name: "week from monday", period: entities.LeaderboardPeriodWeek, now: mondayNight, want: time.Date(2026, time.October, 19, 0, 0, 0, 0, msk)},
+		{name: "week on sunday", period: entities.LeaderboardPeriodWeek, now: sunday, want: time.Date(2026, time.October, 12, 0, 0, 0, 0, msk)},
+		{name: "month", period: entities.LeaderboardPeriodMonth, now: mondayNight, want: time.Date(2026, time.October, 1, 0, 0, 0, 0, msk)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.periodStart(tt.period, tt.now); !got.Equal(tt.want) {
+				t.Fatalf("periodStart() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountUsersLeaderboardReusesTotalWithinTTL(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	st.addUser(nil)
+
+	if got, err := s.countUsersLeaderboard(ctx); err != nil || got != 1 {
+		t.Fatalf("countUsersLeaderboard() = %d, %v, want 1", got, err)
+	}
+
+	// новый пользователь не виден, пока не истёк срок
+	st.addUser(nil)
+	if got, err := s.countUsersLeaderboard(ctx); err != nil || got != 1 {
+		t.Fatalf("cached countUsersLeaderboard() = %d, %v, want 1", got, err)
+	}
+	if st.userCounts != 1 {
+		t.Fatalf("CountUsersLeaderboard calls = %d, want 1", st.userCounts)
+	}
+
+	s.leaderboardTotal.expiresAt = time.Now().Add(-time.Second)
+	if got, err := s.countUsersLeaderboard(ctx); err != nil || got != 2 {
+		t.Fatalf("expired countUsersLeaderboard() = %d, %v, want 2", got, err)
+	}
+	if st.userCounts != 2 {
+		t.Fatalf("CountUsersLeaderboard calls = %d, want 2", st.userCounts)
+	}
+}
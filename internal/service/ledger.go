@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// postLedgerEntry проводит изменение баланса через журнал баллов.
+// Заработанные баллы учитываются в рейтингах за период, с начислений за задания
+// платятся комиссии реферерам. Нулевые изменения не записываются. Должна вызываться внутри транзакции,
+// вместе с изменением, которое стало причиной проводки.
+func (s *Service) postLedgerEntry(ctx context.Context, userID uuid.UUID, amount int, reason entities.LedgerReason, referenceID *uuid.UUID) error {
+	if amount == 0 {
+		return nil
+	}
+
+	entry, err := s.storage.AddLedgerEntry(ctx, entities.LedgerEntry{
+		UserID:      userID,
+		Amount:      amount,
+		Reason:      reason,
+		ReferenceID: referenceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.trackPointsLots(ctx, entry); err != nil {
+		return err
+	}
+
+	if err := s.trackEarnedPoints(ctx, entry); err != nil {
+		return err
+	}
+
+	return s.payReferralCommissions(ctx, entry)
+}
+
+// spendPoints списывает amount баллов через журнал, не допуская отрицательного баланса.
+// Должна вызываться внутри транзакции.
+func (s *Service) spendPoints(ctx context.Context, userID uuid.UUID, amount int, reason entities.LedgerReason, referenceID *uuid.UUID) error {
+	if amount == 0 {
+		return nil
+	}
+
+	entry, err := s.storage.AddLedgerDebit(ctx, entities.LedgerEntry{
+		UserID:      userID,
+		Amount:      -amount,
+		Reason:      reason,
+		ReferenceID: referenceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.trackPointsLots(ctx, entry); err != nil {
+		return err
+	}
+
+	return s.trackEarnedPoints(ctx, entry)
+}
+
+// GetPointsHistory возвращает страницу истории баллов пользователя
+func (s *Service) GetPointsHistory(ctx context.Context, userID uuid.UUID, filter entities.LedgerFilter) (*entities.LedgerPage, error) {
+	exists, err := s.storage.IsUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return s.storage.GetLedgerPage(ctx, userID, filter)
+}
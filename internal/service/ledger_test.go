@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestSpendPointsRequiresFunds(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	userID := st.addUser(nil)
+
+	if err := s.postLedgerEntry(ctx, userID, 50, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	if err := s.spendPoints(ctx, userID, 80, entities.LedgerReasonRedemption, nil); !errors.Is(err, entities.ErrInsufficientPoints) {
+		t.Fatalf("spendPoints() error = %v, want %v", err, entities.ErrInsufficientPoints)
+	}
+	if got := st.balance(t, userID); got != 50 {
+		t.Fatalf("balance after rejected debit = %d, want 50", got)
+	}
+	if lots := st.userLots(userID); len(lots) != 1 || lots[0].Remaining != 50 {
+		t.Fatalf("lots after rejected debit = %+v, want one lot with 50 remaining", lots)
+	}
+
+	if err := s.spendPoints(ctx, userID, 50, entities.LedgerReasonRedemption, nil); err != nil {
+		t.Fatalf("spendPoints() error = %v", err)
+	}
+	if got := st.balance(t, userID); got != 0 {
+		t.Fatalf("balance = %d, want 0", got)
+	}
+	if lots := st.userLots(userID); lots[0].Remaining != 0 {
+		t.Fatalf("lot remaining = %d, want 0", lots[0].Remaining)
+	}
+}
+
+func TestSpendPointsUnknownUser(t *testing.T) {
+	s, st := newTestService()
+	st.addUser(nil)
+
+	if err := s.spendPoints(context.Background(), uuid.New(), 1, entities.LedgerReasonRedemption, nil); !errors.Is(err, entities.ErrUserNotFound) {
+		t.Fatalf("spendPoints() error = %v, want %v", err, entities.ErrUserNotFound)
+	}
+}
+
+func TestPostLedgerEntryRepaysDebtBeforeCreatingLot(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	userID := st.addUser(nil)
+
+	// отзыв без проверки средств уводит баланс в минус
+	if err := s.postLedgerEntry(ctx, userID, -30, entities.LedgerReasonTaskRevoked, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if err := s.postLedgerEntry(ctx, userID, 20, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if lots := st.userLots(userID); len(lots) != 0 {
+		t.Fatalf("lots while in debt = %+v, want none", lots)
+	}
+
+	if err := s.postLedgerEntry(ctx, userID, 50, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if got := st.balance(t, userID); got != 40 {
+		t.Fatalf("balance = %d, want 40", got)
+	}
+	if lots := st.userLots(userID); len(lots) != 1 || lots[0].Remaining != 40 {
+		t.Fatalf("lots = %+v, want one lot with 40 remaining", lots)
+	}
+}
+
+func TestPostLedgerEntrySkipsZeroAmount(t *testing.T) {
+	s, st := newTestService()
+	userID := st.addUser(nil)
+
+	if err := s.postLedgerEntry(context.Background(), userID, 0, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if got := len(st.entries(userID, entities.LedgerReasonAdminAdjustment)); got != 0 {
+		t.Fatalf("entries = %d, want 0", got)
+	}
+}
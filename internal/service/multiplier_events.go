@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// CreateMultiplierEvent создаёт событие-множитель; область действия сохраняется в той же транзакции
+func (s *Service) CreateMultiplierEvent(ctx context.Context, event entities.MultiplierEvent) (*entities.MultiplierEvent, error) {
+	event.TaskIDs = uniqueUUIDs(event.TaskIDs)
+	slices.Sort(event.Categories)
+	event.Categories = slices.Compact(event.Categories)
+
+	var created *entities.MultiplierEvent
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.storage.CreateMultiplierEvent(ctx, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *Service) ListMultiplierEvents(ctx context.Context) ([]*entities.MultiplierEvent, error) {
+	return s.storage.ListMultiplierEvents(ctx)
+}
+
+// uniqueUUIDs убирает повторы, сохраняя порядок
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
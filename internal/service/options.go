@@ -0,0 +1,150 @@
+package service
+
+import (
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+const (
+	defaultLocale = "ru"
+
+	// defaultTransferDailyLimit — сколько баллов пользователь может перевести за сутки
+	defaultTransferDailyLimit = 1000
+	// defaultTransferMinAccountAge — минимальный возраст аккаунта отправителя перевода
+	defaultTransferMinAccountAge = 72 * time.Hour
+
+	// defaultExpiryWarning — за сколько до сгорания баллы показываются в статусе как «скоро сгорят»
+	defaultExpiryWarning = 7 * 24 * time.Hour
+
+	// defaultImportBatchSize — число строк импорта, применяемых в одной транзакции
+	defaultImportBatchSize = 100
+
+	// defaultFraudBurstWindow — окно, в котором считаются приглашения одного реферера
+	defaultFraudBurstWindow = time.Hour
+	// defaultFraudBurstSignups — сколько приглашений за окно ещё не считается всплеском
+	defaultFraudBurstSignups = 5
+	// defaultFraudIdleRatio — доля неактивных приглашённых, при которой сеть реферера подозрительна
+	defaultFraudIdleRatio = 0.8
+)
+
+type Option func(*Service)
+
+// WithDefaultLocale задаёт локаль, на которую откатываются тексты задач,
+// если среди запрошенных локалей нет перевода
+func WithDefaultLocale(locale string) Option {
+	return func(s *Service) {
+		if locale != "" {
+			s.defaultLocale = locale
+		}
+	}
+}
+
+// WithWebhookSecrets задаёт секреты партнёров для проверки подписи вебхуков
+func WithWebhookSecrets(secrets map[string]string) Option {
+	return func(s *Service) {
+		for partner, secret := range secrets {
+			s.webhookSecrets[partner] = []byte(secret)
+		}
+	}
+}
+
+// WithImportBatchSize задаёт число строк импорта выполнений в одной транзакции
+func WithImportBatchSize(size int) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.importBatchSize = size
+		}
+	}
+}
+
+// WithTransferLimits задаёт суточный лимит переводов и минимальный возраст аккаунта отправителя.
+// nil оставляет значение по умолчанию; нулевой лимит запрещает переводы,
+// нулевой возраст снимает ограничение по возрасту аккаунта.
+func WithTransferLimits(dailyLimit *int, minAccountAge *time.Duration) Option {
+	return func(s *Service) {
+		if dailyLimit != nil {
+			s.transferDailyLimit = *dailyLimit
+		}
+		if minAccountAge != nil {
+			s.transferMinAccountAge = *minAccountAge
+		}
+	}
+}
+
+// WithPointsExpiry задаёт срок жизни начислений в днях по причине начисления
+// и окно, в котором баллы показываются как «скоро сгорят».
+// Причины без срока не сгорают; нулевое окно оставляет значение по умолчанию.
+func WithPointsExpiry(days map[string]int, warning time.Duration) Option {
+	return func(s *Service) {
+		for source, n := range days {
+			if n > 0 {
+				s.expiryDays[entities.LedgerReason(source)] = n
+			}
+		}
+		if warning > 0 {
+			s.expiryWarning = warning
+		}
+	}
+}
+
+// WithReferralRewards задаёт бонусы за приглашение: приглашённому, рефереру
+// и сколько заданий должен выполнить приглашённый, прежде чем реферер получит бонус.
+// Нулевые бонусы не начисляются.
+func WithReferralRewards(refereeBonus, referrerBonus, referrerAfterTasks int) Option {
+	return func(s *Service) {
+		s.refereeBonus = max(refereeBonus, 0)
+		s.referrerBonus = max(referrerBonus, 0)
+		s.referrerAfterTasks = max(referrerAfterTasks, 0)
+	}
+}
+
+// WithReferralCommissions задаёт комиссии реферерам в процентах по уровням,
+// начиная с прямого реферера. Уровни глубже maxReferralDepth отбрасываются.
+func WithReferralCommissions(ratesPercent []float64) Option {
+	return func(s *Service) {
+		if len(ratesPercent) > maxReferralDepth {
+			ratesPercent = ratesPercent[:maxReferralDepth]
+		}
+		s.commissionRatesBps = make([]int, len(ratesPercent))
+		for i, rate := range ratesPercent {
+			s.commissionRatesBps[i] = percentToBps(rate)
+		}
+	}
+}
+
+// WithOlderReferrerRequired включает отказ в установке реферера,
+// зарегистрированного позже приглашённого
+func WithOlderReferrerRequired(required bool) Option {
+	return func(s *Service) {
+		s.requireOlderReferrer = required
+	}
+}
+
+// WithFraudScoring включает проверку приглашений: при оценке не ниже threshold
+// бонусы задерживаются до решения администратора. Нулевой порог выключает проверку,
+// нулевые окно, число приглашений и доля оставляют значения по умолчанию.
+func WithFraudScoring(threshold int, burstWindow time.Duration, burstSignups int, idleRatio float64) Option {
+	return func(s *Service) {
+		s.fraudScoreThreshold = max(threshold, 0)
+		if burstWindow > 0 {
+			s.fraudBurstWindow = burstWindow
+		}
+		if burstSignups > 0 {
+			s.fraudBurstSignups = burstSignups
+		}
+		if idleRatio > 0 {
+			s.fraudIdleRatio = idleRatio
+		}
+	}
+}
+
+// WithLeaderboardLocation задаёт зону, в которой считаются границы суток, недели
+// и месяца для рейтингов за период; nil оставляет локальную зону сервера
+func WithLeaderboardLocation(loc *time.Location) Option {
+	return func(s *Service) {
+		if loc != nil {
+			s.leaderboardLocation = loc
+		}
+	}
+}
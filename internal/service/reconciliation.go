@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// ReconcilePoints сверяет балансы пользователей с источником истины: награды за выполнения
+// из user_tasks, в том числе выполненные до появления журнала, вместе с остальными,
+// не связанными с задачами проводками журнала.
+// При fix недостающие проводки записываются задним числом, затем одна проводка
+// reconciliation выравнивает журнал по ожидаемому балансу и выставляет его в users.points.
+// Каждый пользователь исправляется в своей транзакции под блокировкой строки.
+func (s *Service) ReconcilePoints(ctx context.Context, fix bool) (*entities.ReconciliationReport, error) {
+	unposted, err := s.storage.FindUnpostedTaskEntries(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ReconciliationReport{
+		Mismatches: []entities.ReconciliationMismatch{},
+		Fix:        fix,
+	}
+
+	// недостающие проводки дополняют журнал перед сравнением с ожидаемым балансом
+	pending := make(map[uuid.UUID]int)
+	for _, e := range unposted {
+		issue := entities.ReconciliationIssueMissingCompletionEntry
+		if e.Reason == entities.LedgerReasonTaskRevoked {
+			issue = entities.ReconciliationIssueMissingRevocationEntry
+		}
+		report.Mismatches = append(report.Mismatches, entities.ReconciliationMismatch{
+			Issue:       issue,
+			UserID:      e.UserID,
+			ReferenceID: e.ReferenceID,
+			Expected:    e.Amount,
+		})
+		pending[e.UserID] += e.Amount
+	}
+
+	balances, err := s.storage.GetDriftedBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]uuid.UUID, 0, len(balances))
+	for _, b := range balances {
+		users = append(users, b.UserID)
+
+		if b.Points != b.Expected {
+			report.Mismatches = append(report.Mismatches, entities.ReconciliationMismatch{
+				Issue:    entities.ReconciliationIssueBalanceDrift,
+				UserID:   b.UserID,
+				Expected: b.Expected,
+				Actual:   b.Points,
+			})
+		}
+		if ledger := b.LedgerTotal + pending[b.UserID]; ledger != b.Expected {
+			report.Mismatches = append(report.Mismatches, entities.ReconciliationMismatch{
+				Issue:    entities.ReconciliationIssueLedgerDrift,
+				UserID:   b.UserID,
+				Expected: b.Expected,
+				Actual:   ledger,
+			})
+		}
+	}
+
+	if !fix {
+		return report, nil
+	}
+
+	fixed := make(map[uuid.UUID]bool, len(users))
+	for _, userID := range users {
+		if err := s.reconcileUser(ctx, userID); err != nil {
+			return report, err
+		}
+		fixed[userID] = true
+	}
+
+	for i := range report.Mismatches {
+		report.Mismatches[i].Fixed = fixed[report.Mismatches[i].UserID]
+	}
+	report.FixedUsers = len(fixed)
+
+	return report, nil
+}
+
+// reconcileUser проводит недостающие записи журнала, не трогая users.points, и одной
+// проводкой сверки выравнивает журнал и users.points по ожидаемому балансу.
+// Проводки ищутся повторно под блокировкой, поэтому параллельная сверка не запишет их дважды.
+func (s *Service) reconcileUser(ctx context.Context, userID uuid.UUID) error {
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.storage.LockUsers(ctx, userID); err != nil {
+			return err
+		}
+
+		entries, err := s.storage.FindUnpostedTaskEntries(ctx, &userID)
+		if err != nil {
+			return err
+		}
+
+		credited := false
+		for _, e := range entries {
+			if err := s.backfillLedgerEntry(ctx, e); err != nil {
+				return err
+			}
+			credited = credited || e.Amount > 0
+		}
+
+		correction, err := s.storage.AddReconciliationEntry(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if correction != nil {
+			if err := s.trackPointsLots(ctx, correction); err != nil {
+				return err
+			}
+			credited = credited || correction.Amount > 0
+		}
+
+		if !credited {
+			return nil
+		}
+		return s.handleBadgeEvents(ctx, userID, entities.BadgeEventPointsChanged)
+	})
+}
+
+// backfillLedgerEntry записывает пропущенную проводку временем исходного события.
+// Баланс не меняется: награда за выполнение могла уже попасть в users.points мимо журнала,
+// поэтому его выставляет проводка сверки. В отличие от postLedgerEntry, комиссии реферерам
+// не платятся, а заработок не попадает в часовые корзины: иначе давнее начисление
+// считалось бы заработанным сейчас.
+func (s *Service) backfillLedgerEntry(ctx context.Context, e entities.LedgerEntry) error {
+	if e.Amount == 0 {
+		return nil
+	}
+
+	entry, err := s.storage.AddBackfilledLedgerEntry(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	return s.trackPointsLots(ctx, entry)
+}
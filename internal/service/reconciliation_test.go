@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestReconcilePointsBackfillsAndCorrectsBalance(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(
+		WithReferralCommissions([]float64{10}),
+		WithPointsExpiry(map[string]int{"task_completed": 365}, 0),
+	)
+	referrerID := st.addUser(nil)
+	userID := st.addUser(&referrerID)
+
+	// до журнала пользователь выполнил задачу на 30, но входящий баланс перенёс завышенные 40
+	st.userTasks = append(st.userTasks, fakeUserTask{
+		ID: uuid.New(), UserID: userID, Points: 30, CompletedAt: time.Now().AddDate(0, 0, -30),
+	})
+	opening, err := st.AddLedgerEntry(ctx, entities.LedgerEntry{
+		UserID: userID, Amount: 40, Reason: entities.LedgerReasonOpeningBalance, CreatedAt: time.Now().AddDate(0, 0, -20),
+	})
+	if err != nil {
+		t.Fatalf("AddLedgerEntry() error = %v", err)
+	}
+	if err := s.trackPointsLots(ctx, opening); err != nil {
+		t.Fatalf("trackPointsLots() error = %v", err)
+	}
+
+	if err := s.postLedgerEntry(ctx, userID, 20, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	earnedBefore := st.earned[userID]
+
+	// награда за выполнение попала в users.points, но не в журнал; ещё 7 баллов добавлены мимо журнала
+	completedAt := time.Now().AddDate(0, 0, -10).Truncate(time.Second)
+	st.userTasks = append(st.userTasks, fakeUserTask{ID: uuid.New(), UserID: userID, Points: 50, CompletedAt: completedAt})
+	u := st.users[userID]
+	u.Points += 50 + 7
+	st.users[userID] = u
+
+	// ожидаемый баланс: 30 + 50 за выполнения и 20 корректировки
+	const expected = 100
+
+	report, err := s.ReconcilePoints(ctx, false)
+	if err != nil {
+		t.Fatalf("ReconcilePoints() error = %v", err)
+	}
+	if len(report.Mismatches) != 3 || report.FixedUsers != 0 {
+		t.Fatalf("report = %+v, want 3 unfixed mismatches", report)
+	}
+	for _, m := range report.Mismatches {
+		switch m.Issue {
+		case entities.ReconciliationIssueMissingCompletionEntry:
+			if m.Expected != 50 {
+				t.Fatalf("missing entry = %+v, want 50", m)
+			}
+		case entities.ReconciliationIssueBalanceDrift:
+			if m.Expected != expected || m.Actual != 117 {
+				t.Fatalf("balance drift = %+v, want %d against 117", m, expected)
+			}
+		case entities.ReconciliationIssueLedgerDrift:
+			if m.Expected != expected || m.Actual != 110 {
+				t.Fatalf("ledger drift = %+v, want %d against 110", m, expected)
+			}
+		default:
+			t.Fatalf("unexpected mismatch %+v", m)
+		}
+	}
+	if len(st.entries(userID, entities.LedgerReasonTaskCompleted)) != 0 {
+		t.Fatal("dry run posted ledger entries")
+	}
+
+	report, err = s.ReconcilePoints(ctx, true)
+	if err != nil {
+		t.Fatalf("ReconcilePoints() error = %v", err)
+	}
+	if report.FixedUsers != 1 {
+		t.Fatalf("fixed users = %d, want 1", report.FixedUsers)
+	}
+	for _, m := range report.Mismatches {
+		if !m.Fixed {
+			t.Fatalf("mismatch %+v is not fixed", m)
+		}
+	}
+
+	backfilled := st.entries(userID, entities.LedgerReasonTaskCompleted)
+	if len(backfilled) != 1 || backfilled[0].Amount != 50 || !backfilled[0].CreatedAt.Equal(completedAt) {
+		t.Fatalf("backfilled entries = %+v, want one entry of 50 at %v", backfilled, completedAt)
+	}
+	if got := st.entries(userID, entities.LedgerReasonReconciliation); len(got) != 1 || got[0].Amount != -10 {
+		t.Fatalf("reconciliation entries = %+v, want one entry of -10", got)
+	}
+
+	// завышенный баланс исправлен, а журнал сходится с users.points
+	if got := st.balance(t, userID); got != expected {
+		t.Fatalf("balance = %d, want %d", got, expected)
+	}
+	var remaining int
+	for _, lot := range st.userLots(userID) {
+		remaining += lot.Remaining
+	}
+	if remaining != expected {
+		t.Fatalf("lots remaining = %d, want %d", remaining, expected)
+	}
+
+	// задним числом не платятся комиссии и не пополняются рейтинги за период
+	if len(st.commissions) != 0 || st.balance(t, referrerID) != 0 {
+		t.Fatalf("commissions = %+v, want none", st.commissions)
+	}
+	if st.earned[userID] != earnedBefore {
+		t.Fatalf("earned = %d, want %d", st.earned[userID], earnedBefore)
+	}
+
+	// повторная сверка ничего не находит
+	report, err = s.ReconcilePoints(ctx, true)
+	if err != nil {
+		t.Fatalf("ReconcilePoints() error = %v", err)
+	}
+	if len(report.Mismatches) != 0 {
+		t.Fatalf("mismatches after fix = %+v, want none", report.Mismatches)
+	}
+}
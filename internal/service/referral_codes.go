@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"service-boilerplate-go/internal/pkg/refcode"
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// referralCodeAttempts — сколько раз генерируется новый код при коллизии
+const referralCodeAttempts = 5
+
+// createUser создаёт пользователя со случайным кодом приглашения.
+// Уникальность проверяет индекс в базе: при коллизии код генерируется заново.
+// Каждая попытка — отдельная (вложенная) транзакция, чтобы коллизия не прерывала внешнюю.
+func (s *Service) createUser(ctx context.Context, username, passwordHash string, signup entities.SignupInfo) (*entities.User, error) {
+	for range referralCodeAttempts {
+		code, err := refcode.Generate()
+		if err != nil {
+			return nil, err
+		}
+
+		var user *entities.User
+		err = s.txManager.Do(ctx, func(ctx context.Context) error {
+			var err error
+			user, err = s.storage.CreateUser(ctx, username, passwordHash, code, signup)
+			return err
+		})
+		if errors.Is(err, entities.ErrReferralCodeTaken) {
+			continue
+		}
+		return user, err
+	}
+
+	return nil, entities.ErrReferralCodeTaken
+}
+
+// registerUser создаёт пользователя и, если регистрация пришла по приглашению,
+// сразу устанавливает реферера. Создание и бонусы за приглашение выполняются в одной
+// транзакции: пользователь не может появиться без реферера, указанного в ссылке.
+func (s *Service) registerUser(ctx context.Context, username, passwordHash string, signup entities.SignupInfo) (*entities.User, error) {
+	if signup.ReferralCode != "" {
+		referrer, err := s.GetUserByReferralCode(ctx, signup.ReferralCode)
+		if err != nil {
+			return nil, err
+		}
+		signup.ReferrerID = &referrer.ID
+	} else if signup.ReferrerID != nil {
+		exists, err := s.storage.IsUserExists(ctx, *signup.ReferrerID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entities.ErrUserNotFound
+		}
+	}
+
+	if signup.ReferrerID == nil {
+		return s.createUser(ctx, username, passwordHash, signup)
+	}
+
+	// у нового пользователя нет приглашённых, поэтому проверка цикла из InputReferrer
+	// не нужна, но цепочку реферера, как и там, блокируем до начислений
+	var user *entities.User
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.createUser(ctx, username, passwordHash, signup)
+		if err != nil {
+			return err
+		}
+
+		if err := s.lockReferralChains(ctx, user.ID, *signup.ReferrerID); err != nil {
+			return err
+		}
+
+		return s.handleReferrerSet(ctx, user.ID, *signup.ReferrerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetUserByReferralCode находит пользователя по коду приглашения без учёта регистра
+func (s *Service) GetUserByReferralCode(ctx context.Context, code string) (*entities.User, error) {
+	code = refcode.Normalize(code)
+	if code == "" {
+		return nil, entities.ErrReferralCodeNotFound
+	}
+
+	return s.storage.GetUserByReferralCode(ctx, code)
+}
+
+// InputReferrerByCode устанавливает реферера по его коду приглашения
+func (s *Service) InputReferrerByCode(ctx context.Context, userID uuid.UUID, code string) error {
+	referrer, err := s.GetUserByReferralCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	return s.InputReferrer(ctx, userID, referrer.ID)
+}
+
+// SetReferralCode заменяет код приглашения пользователя на выбранный им.
+// Код хранится в верхнем регистре, поэтому «Alice» и «ALICE» — один и тот же код.
+func (s *Service) SetReferralCode(ctx context.Context, userID uuid.UUID, code string) (string, error) {
+	code = refcode.Normalize(code)
+	if !refcode.ValidVanity(code) {
+		return "", entities.ErrInvalidReferralCode
+	}
+
+	if err := s.storage.UpdateUserReferralCode(ctx, userID, code); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestAuthSignupByReferralCodeCreditsReferrerChain(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithReferralCommissions([]float64{10}))
+
+	inviteTaskID := st.addTask(100, 1, nil)
+	inviteTask := st.tasks[inviteTaskID]
+	inviteTask.ProgressEvent = entities.ProgressEventReferralJoined
+	st.tasks[inviteTaskID] = inviteTask
+
+	// top ← referrer: приглашение засчитывается рефереру, комиссия с награды — top
+	top := st.addUser(nil)
+	referrerID := st.addUser(&top)
+	referrer := st.users[referrerID]
+	referrer.ReferralCode = "ALICE"
+	st.users[referrerID] = referrer
+
+	_, userID, err := s.Auth(ctx, "newbie", "password", entities.SignupInfo{ReferralCode: "alice"})
+	if err != nil {
+		t.Fatalf("Auth() error = %v", err)
+	}
+
+	if got := st.users[userID].ReferrerID; got == nil || *got != referrerID {
+		t.Fatalf("referrer of new user = %v, want %s", got, referrerID)
+	}
+	if got := st.balance(t, referrerID); got != 100 {
+		t.Fatalf("referrer balance = %d, want 100", got)
+	}
+	if got := st.balance(t, top); got != 10 {
+		t.Fatalf("top balance = %d, want 10", got)
+	}
+
+	// строки нового пользователя и всей цепочки блокируются одним вызовом до начислений
+	if len(st.locks) == 0 {
+		t.Fatal("LockUsers was not called")
+	}
+	for _, id := range []uuid.UUID{userID, referrerID, top} {
+		if !slices.Contains(st.locks[0], id) {
+			t.Fatalf("first LockUsers(%v) does not lock %s", st.locks[0], id)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// maxReferralDepth — предельная глубина цепочки рефереров для комиссий и дерева приглашённых
+const maxReferralDepth = 10
+
+// commissionableReasons — начисления, с которых платятся комиссии реферерам.
+// Переводы, бонусы и сами комиссии не учитываются, иначе баллы размножались бы по цепочке.
+var commissionableReasons = map[entities.LedgerReason]bool{
+	entities.LedgerReasonTaskCompleted: true,
+}
+
+// lockReferralChains блокирует строки пользователей вместе с их реферерами, которым
+// начисление пользователя может принести бонус или комиссию. Все строки блокируются
+// одним запросом в порядке id — в том же порядке, что и у переводов и ручных
+// корректировок, — поэтому начисления по цепочке не дают с ними взаимной блокировки.
+// Цепочка меняется только при установке реферера, которая сама блокирует её заранее.
+// Должна вызываться внутри транзакции до первого изменения баланса.
+func (s *Service) lockReferralChains(ctx context.Context, userIDs ...uuid.UUID) error {
+	depth := max(len(s.commissionRatesBps), 1)
+
+	ids := slices.Clone(userIDs)
+	for _, userID := range userIDs {
+		chain, err := s.storage.GetReferrerChain(ctx, userID, depth)
+		if err != nil {
+			return err
+		}
+		for _, ancestor := range chain {
+			ids = append(ids, ancestor.UserID)
+		}
+	}
+
+	_, err := s.storage.LockUsers(ctx, ids...)
+	return err
+}
+
+// payReferralCommissions начисляет комиссии реферерам пользователя с его начисления.
+// Пока приглашение пользователя не одобрено на проверке, комиссии задерживаются
+// и выплачиваются при одобрении через payHeldReferralCommissions.
+// Строки рефереров должны быть заранее заблокированы через lockReferralChains.
+// Должна вызываться в транзакции начисления.
+func (s *Service) payReferralCommissions(ctx context.Context, entry *entities.LedgerEntry) error {
+	if len(s.commissionRatesBps) == 0 || entry.Amount <= 0 || !commissionableReasons[entry.Reason] {
+		return nil
+	}
+
+	held, err := s.isReferralHeld(ctx, entry.UserID)
+	if err != nil || held {
+		return err
+	}
+
+	chain, err := s.storage.GetReferrerChain(ctx, entry.UserID, len(s.commissionRatesBps))
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range chain {
+		rate := s.commissionRatesBps[ancestor.Level-1]
+		amount := entry.Amount * rate / 10000
+		if amount == 0 {
+			continue
+		}
+
+		commission := entities.ReferralCommission{
+			ID:            uuid.New(),
+			BeneficiaryID: ancestor.UserID,
+			SourceUserID:  entry.UserID,
+			Level:         ancestor.Level,
+			SourceEntryID: entry.ID,
+			SourceReason:  entry.Reason,
+			SourceAmount:  entry.Amount,
+			RateBps:       rate,
+			Amount:        amount,
+		}
+		if _, err := s.storage.CreateReferralCommission(ctx, commission); err != nil {
+			return err
+		}
+
+		if err := s.postLedgerEntry(ctx, ancestor.UserID, amount, entities.LedgerReasonReferralCommission, &commission.ID); err != nil {
+			return err
+		}
+		if err := s.handleBadgeEvents(ctx, ancestor.UserID, entities.BadgeEventPointsChanged); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// payHeldReferralCommissions выплачивает комиссии с начислений приглашённого, задержанные
+// с момента постановки приглашения на проверку. Должна вызываться в транзакции одобрения.
+func (s *Service) payHeldReferralCommissions(ctx context.Context, review *entities.ReferralReview) error {
+	if len(s.commissionRatesBps) == 0 {
+		return nil
+	}
+
+	entries, err := s.storage.GetUncommissionedTaskEntries(ctx, review.RefereeID, review.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		if err := s.payReferralCommissions(ctx, &entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reverseReferralCommissions списывает с рефереров комиссии, выплаченные с отозванного
+// начисления reason по reference, и возвращает списанную сумму. Списание, как и отзыв,
+// может увести баланс реферера в минус, если комиссия уже потрачена.
+// Должна вызываться в транзакции отзыва.
+func (s *Service) reverseReferralCommissions(ctx context.Context, reason entities.LedgerReason, referenceID uuid.UUID) (int, error) {
+	commissions, err := s.storage.ReverseReferralCommissions(ctx, reason, referenceID)
+	if err != nil {
+		return 0, err
+	}
+
+	var reversed int
+	for _, c := range commissions {
+		if err := s.postLedgerEntry(ctx, c.BeneficiaryID, -c.Amount, entities.LedgerReasonReferralCommissionReversed, &c.ID); err != nil {
+			return 0, err
+		}
+		if err := s.handleBadgeEvents(ctx, c.BeneficiaryID, entities.BadgeEventPointsChanged); err != nil {
+			return 0, err
+		}
+		reversed += c.Amount
+	}
+
+	return reversed, nil
+}
+
+// GetReferralTree возвращает сеть приглашённых пользователя с заработанными на ней комиссиями.
+// depth ограничивается maxReferralDepth; 0 — глубина по числу уровней комиссий, но не меньше 1.
+func (s *Service) GetReferralTree(ctx context.Context, userID uuid.UUID, depth int) (*entities.ReferralTree, error) {
+	exists, err := s.storage.IsUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	if depth <= 0 {
+		depth = max(len(s.commissionRatesBps), 1)
+	}
+	depth = min(depth, maxReferralDepth)
+
+	nodes, err := s.storage.GetReferralTree(ctx, userID, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &entities.ReferralTree{
+		UserID: userID,
+		Depth:  depth,
+		Nodes:  nodes,
+	}
+	for _, n := range nodes {
+		tree.TotalEarned += n.Earned
+	}
+
+	return tree, nil
+}
+
+// percentToBps переводит проценты в сотые доли процента
+func percentToBps(percent float64) int {
+	return int(math.Round(percent * 100))
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestPayReferralCommissionsStopsAtConfiguredDepth(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithReferralCommissions([]float64{10, 5}))
+
+	// top ← upper ← direct ← user: комиссии получают только два уровня
+	top := st.addUser(nil)
+	upper := st.addUser(&top)
+	direct := st.addUser(&upper)
+	userID := st.addUser(&direct)
+
+	if err := s.postLedgerEntry(ctx, userID, 100, entities.LedgerReasonTaskCompleted, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	if got := st.balance(t, direct); got != 10 {
+		t.Fatalf("direct referrer balance = %d, want 10", got)
+	}
+	if got := st.balance(t, upper); got != 5 {
+		t.Fatalf("second level balance = %d, want 5", got)
+	}
+	if got := st.balance(t, top); got != 0 {
+		t.Fatalf("third level balance = %d, want 0", got)
+	}
+
+	if len(st.commissions) != 2 {
+		t.Fatalf("commissions = %d, want 2", len(st.commissions))
+	}
+	for i, c := range st.commissions {
+		if c.Level != i+1 || c.SourceUserID != userID || c.SourceAmount != 100 {
+			t.Fatalf("commission %d = %+v", i, c)
+		}
+	}
+
+	// комиссии сами не порождают комиссий выше по цепочке
+	if got := len(st.entries(upper, entities.LedgerReasonReferralCommission)); got != 1 {
+		t.Fatalf("second level commission entries = %d, want 1", got)
+	}
+}
+
+func TestPayReferralCommissionsOnlyForTaskCredits(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithReferralCommissions([]float64{10}))
+	referrerID := st.addUser(nil)
+	userID := st.addUser(&referrerID)
+
+	for _, reason := range []entities.LedgerReason{
+		entities.LedgerReasonTransferIn,
+		entities.LedgerReasonAdminAdjustment,
+		entities.LedgerReasonRefereeBonus,
+	} {
+		if err := s.postLedgerEntry(ctx, userID, 100, reason, nil); err != nil {
+			t.Fatalf("postLedgerEntry(%s) error = %v", reason, err)
+		}
+	}
+	// 10% от 9 округляется вниз до нуля и не записывается
+	if err := s.postLedgerEntry(ctx, userID, 9, entities.LedgerReasonTaskCompleted, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	if len(st.commissions) != 0 {
+		t.Fatalf("commissions = %+v, want none", st.commissions)
+	}
+	if got := st.balance(t, referrerID); got != 0 {
+		t.Fatalf("referrer balance = %d, want 0", got)
+	}
+}
+
+func TestCompleteTaskLocksReferrerChainUpFront(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithReferralCommissions([]float64{10, 5}))
+	taskID := st.addTask(100, 1, nil)
+
+	// строки рефереров, получающих комиссию, блокируются одним вызовом вместе с пользователем
+	top := st.addUser(nil)
+	upper := st.addUser(&top)
+	direct := st.addUser(&upper)
+	userID := st.addUser(&direct)
+
+	if err := s.CompleteTask(ctx, userID, taskID, nil); err != nil {
+		t.Fatalf("CompleteTask() error = %v", err)
+	}
+
+	if len(st.locks) != 1 {
+		t.Fatalf("LockUsers calls = %d, want 1", len(st.locks))
+	}
+	want := []uuid.UUID{userID, direct, upper}
+	if !slices.Equal(st.locks[0], want) {
+		t.Fatalf("LockUsers(%v), want %v", st.locks[0], want)
+	}
+	if got := st.balance(t, upper); got != 5 {
+		t.Fatalf("second level balance = %d, want 5", got)
+	}
+}
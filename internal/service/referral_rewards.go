@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// grantReferralRewards оценивает приглашение, фиксирует бонусы за него, начисляет бонус
+// приглашённому и, если условие уже выполнено, рефереру. Приглашение оценивается и при
+// выключенных бонусах: от оценки зависят прогресс реферера и комиссии. Подозрительное
+// приглашение уходит на проверку, бонусы задерживаются, и возвращается true.
+// Должна вызываться в транзакции, в которой устанавливается referrer_id.
+func (s *Service) grantReferralRewards(ctx context.Context, refereeID, referrerID uuid.UUID) (bool, error) {
+	if s.refereeBonus != 0 || s.referrerBonus != 0 {
+		_, err := s.storage.CreateReferralReward(ctx, entities.ReferralReward{
+			RefereeID:          refereeID,
+			ReferrerID:         referrerID,
+			RefereeBonus:       s.refereeBonus,
+			ReferrerBonus:      s.referrerBonus,
+			ReferrerAfterTasks: s.referrerAfterTasks,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	held, err := s.holdSuspiciousReferral(ctx, refereeID, referrerID, entities.ReferralReviewTriggerReferrerSet)
+	if err != nil || held {
+		return held, err
+	}
+
+	if err := s.payRefereeReward(ctx, refereeID); err != nil {
+		return false, err
+	}
+
+	return false, s.claimReferrerReward(ctx, refereeID)
+}
+
+// payReferrerReward начисляет рефереру бонус, когда приглашённый выполнил нужное
+// число заданий. Перед начислением приглашение оценивается повторно: к этому моменту
+// видно, выполняют ли приглашённые реферера что-то кроме реферальных заданий.
+// Вызывается после каждого выполнения; повторные вызовы ничего не начисляют.
+// Должна вызываться внутри транзакции.
+func (s *Service) payReferrerReward(ctx context.Context, refereeID uuid.UUID) error {
+	reward, err := s.storage.GetDueReferrerReward(ctx, refereeID)
+	if err != nil || reward == nil {
+		return err
+	}
+
+	held, err := s.holdSuspiciousReferral(ctx, refereeID, reward.ReferrerID, entities.ReferralReviewTriggerTaskCompleted)
+	if err != nil || held {
+		return err
+	}
+
+	return s.claimReferrerReward(ctx, refereeID)
+}
+
+// payRefereeReward начисляет приглашённому его бонус, если он не задержан и ещё не начислен
+func (s *Service) payRefereeReward(ctx context.Context, refereeID uuid.UUID) error {
+	bonus, err := s.storage.ClaimRefereeReward(ctx, refereeID)
+	if err != nil || bonus == 0 {
+		return err
+	}
+
+	if err := s.postLedgerEntry(ctx, refereeID, bonus, entities.LedgerReasonRefereeBonus, &refereeID); err != nil {
+		return err
+	}
+
+	return s.handleBadgeEvents(ctx, refereeID, entities.BadgeEventPointsChanged)
+}
+
+// claimReferrerReward отмечает бонус реферера начисленным и начисляет его без повторной оценки
+func (s *Service) claimReferrerReward(ctx context.Context, refereeID uuid.UUID) error {
+	reward, err := s.storage.ClaimReferrerReward(ctx, refereeID)
+	if err != nil || reward == nil || reward.ReferrerBonus == 0 {
+		return err
+	}
+
+	if err := s.postLedgerEntry(ctx, reward.ReferrerID, reward.ReferrerBonus, entities.LedgerReasonReferrerBonus, &refereeID); err != nil {
+		return err
+	}
+
+	return s.handleBadgeEvents(ctx, reward.ReferrerID, entities.BadgeEventPointsChanged)
+}
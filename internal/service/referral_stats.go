@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// GetReferralStats возвращает сводку по прямым приглашённым пользователя
+// и страницу приглашённых. Периоды отсчитываются от текущего момента.
+func (s *Service) GetReferralStats(ctx context.Context, userID uuid.UUID, filter entities.RefereeFilter) (*entities.ReferralStats, error) {
+	exists, err := s.storage.IsUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	var (
+		summary *entities.ReferralSummary
+		page    *entities.RefereePage
+		now     = time.Now()
+	)
+
+	g, groupCtx := errgroup.WithContext(ctx)
+
+	// сводка и страница приглашённых независимы, читаем параллельно
+	g.Go(func() error {
+		var err error
+		summary, err = s.storage.GetReferralSummary(groupCtx, userID,
+			now.AddDate(0, 0, -1),
+			now.AddDate(0, 0, -7),
+			now.AddDate(0, 0, -30),
+		)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		page, err = s.storage.GetRefereesPage(groupCtx, userID, filter)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &entities.ReferralStats{
+		UserID:          userID,
+		ReferralSummary: *summary,
+		RefereePage:     *page,
+	}, nil
+}
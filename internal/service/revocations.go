@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+// RevokeTaskCompletion отзывает выполнение задачи и списывает начисленные за него баллы
+// вместе с комиссиями, выплаченными с этого начисления реферерам.
+// Отзыв, списания и сброс прогресса выполняются в одной транзакции.
+//
+// Списание не проверяет остаток: если баллы уже потрачены, баланс уходит в минус
+// и следующие начисления сначала гасят долг. Отрицательный баланс возвращается
+// в BalanceAfter, чтобы администратор видел, что списать полностью не удалось.
+func (s *Service) RevokeTaskCompletion(ctx context.Context, revocation entities.TaskRevocation) (*entities.RevokedTaskCompletion, error) {
+	var result *entities.RevokedTaskCompletion
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		revoked, err := s.storage.RevokeUserTask(ctx, revocation)
+		if err != nil {
+			return err
+		}
+
+		// комиссии списываются с рефереров, их строки блокируются вместе со строкой пользователя
+		if err := s.lockReferralChains(ctx, revoked.UserID); err != nil {
+			return err
+		}
+
+		// списываем ровно то, что было начислено, с учётом множителя
+		if err := s.postLedgerEntry(ctx, revoked.UserID, -revoked.Points, entities.LedgerReasonTaskRevoked, &revoked.ID); err != nil {
+			return err
+		}
+
+		// слот лимита освобождаем после списания: строка пользователя блокируется
+		// раньше строки задачи, как и при выполнении
+		if err := s.storage.ReleaseTaskSlot(ctx, revoked.TaskID); err != nil {
+			return err
+		}
+
+		reversed, err := s.reverseReferralCommissions(ctx, entities.LedgerReasonTaskCompleted, revoked.ID)
+		if err != nil {
+			return err
+		}
+
+		// при разрешённом повторном выполнении задачу со счётчиком начинаем заново
+		if revoked.AllowRecomplete {
+			if err := s.storage.ResetTaskProgress(ctx, revoked.UserID, revoked.TaskID); err != nil {
+				return err
+			}
+		}
+
+		// уже выданные достижения не отзываются
+		if err := s.handleBadgeEvents(ctx, revoked.UserID, entities.BadgeEventPointsChanged); err != nil {
+			return err
+		}
+
+		user, err := s.storage.GetUserByID(ctx, revoked.UserID)
+		if err != nil {
+			return err
+		}
+
+		result = &entities.RevokedTaskCompletion{
+			UserTask:            *revoked,
+			BalanceAfter:        user.Points,
+			CommissionsReversed: reversed,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
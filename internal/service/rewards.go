@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateReward(ctx context.Context, reward entities.Reward) (*entities.Reward, error) {
+	return s.storage.CreateReward(ctx, reward)
+}
+
+// ListRewards возвращает доступные для покупки награды
+func (s *Service) ListRewards(ctx context.Context) ([]*entities.Reward, error) {
+	return s.storage.ListRewards(ctx, true)
+}
+
+// RedeemReward оформляет заказ награды и списывает её цену.
+// Остаток, лимит на пользователя и баланс проверяются в одной транзакции:
+// строка награды блокируется первой, строка пользователя — второй,
+// поэтому конкурентные заказы выполняются последовательно.
+func (s *Service) RedeemReward(ctx context.Context, userID, rewardID uuid.UUID) (*entities.Redemption, error) {
+	var redemption *entities.Redemption
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		reward, err := s.storage.ReserveRewardStock(ctx, rewardID)
+		if err != nil {
+			return err
+		}
+
+		// ID заказа нужен заранее: на него ссылается запись журнала
+		redemptionID := uuid.New()
+
+		// списание блокирует строку пользователя, после этого подсчёт заказов точен
+		if err := s.spendPoints(ctx, userID, reward.Price, entities.LedgerReasonRedemption, &redemptionID); err != nil {
+			return err
+		}
+
+		if reward.PerUserLimit != nil {
+			count, err := s.storage.CountUserRedemptions(ctx, userID, reward.ID)
+			if err != nil {
+				return err
+			}
+			if count >= *reward.PerUserLimit {
+				return entities.ErrRewardLimitReached
+			}
+		}
+
+		redemption, err = s.storage.CreateRedemption(ctx, entities.Redemption{
+			ID:         redemptionID,
+			UserID:     userID,
+			RewardID:   reward.ID,
+			RewardCode: reward.Code,
+			Price:      reward.Price,
+			Status:     entities.RedemptionStatusPending,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// GetUserRedemptions возвращает заказы пользователя, новые первыми
+func (s *Service) GetUserRedemptions(ctx context.Context, userID uuid.UUID) ([]*entities.Redemption, error) {
+	exists, err := s.storage.IsUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return s.storage.ListRedemptions(ctx, entities.RedemptionFilter{UserID: &userID})
+}
+
+func (s *Service) ListRedemptions(ctx context.Context, filter entities.RedemptionFilter) ([]*entities.Redemption, error) {
+	return s.storage.ListRedemptions(ctx, filter)
+}
+
+// DecideRedemption выдаёт или отменяет ожидающий заказ.
+// При отмене цена возвращается на баланс, а единица — на склад.
+func (s *Service) DecideRedemption(ctx context.Context, decision entities.RedemptionDecision) (*entities.Redemption, error) {
+	var redemption *entities.Redemption
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		redemption, err = s.storage.DecideRedemption(ctx, decision)
+		if err != nil {
+			return err
+		}
+
+		if redemption.Status != entities.RedemptionStatusCancelled {
+			return nil
+		}
+
+		if err := s.storage.ReleaseRewardStock(ctx, redemption.RewardID); err != nil {
+			return err
+		}
+
+		return s.postLedgerEntry(ctx, redemption.UserID, redemption.Price, entities.LedgerReasonRedemptionRefund, &redemption.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	entities2 "service-boilerplate-go/internal/service/entities"
 
@@ -9,32 +10,180 @@ import (
 )
 
 type Storage interface {
-	CreateUser(ctx context.Context, username, passwordHash string) (*entities2.User, error)
+	CreateUser(ctx context.Context, username, passwordHash, referralCode string, signup entities2.SignupInfo) (*entities2.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*entities2.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*entities2.User, error)
+	GetUserByReferralCode(ctx context.Context, code string) (*entities2.User, error)
+	UpdateUserReferralCode(ctx context.Context, userID uuid.UUID, code string) error
 
 	GetUsersLeaderboard(ctx context.Context, limit, offset int) (entities2.UsersLeaderboard, error)
+	CountUsersLeaderboard(ctx context.Context) (int, error)
+	GetUserLeaderboardPosition(ctx context.Context, userID uuid.UUID) (*entities2.UserLeader, error)
+	CountTiedAhead(ctx context.Context, userID uuid.UUID, points int) (int, error)
+	GetLeaderboardRank(ctx context.Context, points int) (int, error)
+	GetLeaderboardAbove(ctx context.Context, target entities2.UserLeader, limit int) (entities2.UsersLeaderboard, error)
+	GetLeaderboardBelow(ctx context.Context, target entities2.UserLeader, limit int) (entities2.UsersLeaderboard, error)
+	GetPeriodLeaderboardPage(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) (*entities2.LeaderboardPage, error)
+	AddEarnedPoints(ctx context.Context, userID uuid.UUID, at time.Time, amount int) error
 
 	IsUserExists(ctx context.Context, id uuid.UUID) (bool, error)
 	UpdateUserReferrer(ctx context.Context, userID, referrerID uuid.UUID) error
 
-	IsTaskExists(ctx context.Context, taskID uuid.UUID) (bool, error)
+	GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entities2.Task, error)
+	GetTaskByCode(ctx context.Context, code string) (*entities2.Task, error)
+	GetTasksByProgressEvent(ctx context.Context, event entities2.ProgressEvent) ([]*entities2.Task, error)
 	IsTaskCompleted(ctx context.Context, userID, taskID uuid.UUID) (bool, error)
+	IncrementTaskProgress(ctx context.Context, userID, taskID uuid.UUID, delta, target int) (progress int, err error)
 
-	GetUserStatus(ctx context.Context, userID uuid.UUID) (*entities2.UserStatus, error)
+	GetUserStatus(ctx context.Context, userID uuid.UUID, locales []string) (*entities2.UserStatus, error)
 
-	MarkTaskCompleted(ctx context.Context, userID, taskID uuid.UUID) (userTaskID uuid.UUID, err error)
+	ListTasks(ctx context.Context, filter entities2.TaskFilter, locales []string) ([]*entities2.Task, error)
+	GetTaskTranslations(ctx context.Context, taskID uuid.UUID) ([]entities2.TaskTranslation, error)
+	UpsertTaskTranslation(ctx context.Context, tr entities2.TaskTranslation) (*entities2.TaskTranslation, error)
+	DeleteTaskTranslation(ctx context.Context, taskID uuid.UUID, locale string) error
+
+	CreateMultiplierEvent(ctx context.Context, event entities2.MultiplierEvent) (*entities2.MultiplierEvent, error)
+	ListMultiplierEvents(ctx context.Context) ([]*entities2.MultiplierEvent, error)
+	GetActiveMultiplierEvent(ctx context.Context, taskID uuid.UUID, at time.Time) (*entities2.MultiplierEvent, error)
+
+	GetPendingBadges(ctx context.Context, userID uuid.UUID, event entities2.BadgeEvent) ([]*entities2.Badge, error)
+	GetUserBadgeMetric(ctx context.Context, userID uuid.UUID, metric entities2.BadgeMetric) (int, error)
+	IsWithinLeaderboardRank(ctx context.Context, userID uuid.UUID, rank int) (bool, error)
+	AwardBadge(ctx context.Context, userID, badgeID uuid.UUID) (bool, error)
+	GetUserBadges(ctx context.Context, userID uuid.UUID) ([]entities2.UserBadge, error)
+
+	SaveUserTaskPayload(ctx context.Context, userTaskID uuid.UUID, payload []byte) error
+	SaveWebhookNonce(ctx context.Context, partner, nonce string, expiredBefore time.Time) error
+	GetPartnerUserID(ctx context.Context, partner, externalUserID string) (uuid.UUID, error)
+	UpsertPartnerUserLink(ctx context.Context, link entities2.PartnerUserLink) (*entities2.PartnerUserLink, error)
+
+	ReserveTaskSlot(ctx context.Context, taskID uuid.UUID) error
+	ReleaseTaskSlot(ctx context.Context, taskID uuid.UUID) error
+	MarkTaskCompleted(ctx context.Context, userID, taskID uuid.UUID, reward entities2.TaskReward) (userTaskID uuid.UUID, err error)
 	MarkTaskMetadata(ctx context.Context, userTaskID uuid.UUID, metadata map[string]string) error
+	RevokeUserTask(ctx context.Context, revocation entities2.TaskRevocation) (*entities2.UserTask, error)
+	ResetTaskProgress(ctx context.Context, userID, taskID uuid.UUID) error
+
+	CreateReward(ctx context.Context, reward entities2.Reward) (*entities2.Reward, error)
+	ListRewards(ctx context.Context, activeOnly bool) ([]*entities2.Reward, error)
+	ReserveRewardStock(ctx context.Context, rewardID uuid.UUID) (*entities2.Reward, error)
+	ReleaseRewardStock(ctx context.Context, rewardID uuid.UUID) error
+	CountUserRedemptions(ctx context.Context, userID, rewardID uuid.UUID) (int, error)
+	CreateRedemption(ctx context.Context, redemption entities2.Redemption) (*entities2.Redemption, error)
+	ListRedemptions(ctx context.Context, filter entities2.RedemptionFilter) ([]*entities2.Redemption, error)
+	DecideRedemption(ctx context.Context, decision entities2.RedemptionDecision) (*entities2.Redemption, error)
+
+	LockUsers(ctx context.Context, ids ...uuid.UUID) ([]uuid.UUID, error)
+	IsUserRegisteredBefore(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error)
+	SumUserTransfersSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	CreateTransfer(ctx context.Context, transfer entities2.PointsTransfer) (*entities2.PointsTransfer, error)
+
+	CreatePointsLot(ctx context.Context, lot entities2.PointsLot) (*entities2.PointsLot, error)
+	ConsumePointsLots(ctx context.Context, userID uuid.UUID, amount int) error
+	GetUsersWithExpiredLots(ctx context.Context, at time.Time, limit int) ([]uuid.UUID, error)
+	ExpireUserLots(ctx context.Context, userID uuid.UUID, at time.Time) ([]entities2.PointsLot, error)
+	GetExpiringPoints(ctx context.Context, userID uuid.UUID, before time.Time) ([]entities2.ExpiringPoints, error)
+
+	CreateAdjustmentBatch(ctx context.Context, key, requestHash string) (bool, error)
+	GetAdjustmentBatch(ctx context.Context, key string) (*entities2.PointsAdjustmentBatch, error)
+	CreateAdjustment(ctx context.Context, key string, position int, adjustment entities2.PointsAdjustment) (*entities2.PointsAdjustment, error)
+
+	FindUnpostedTaskEntries(ctx context.Context, userID *uuid.UUID) ([]entities2.LedgerEntry, error)
+	GetDriftedBalances(ctx context.Context) ([]entities2.UserBalance, error)
+	AddBackfilledLedgerEntry(ctx context.Context, entry entities2.LedgerEntry) (*entities2.LedgerEntry, error)
+	AddReconciliationEntry(ctx context.Context, userID uuid.UUID) (*entities2.LedgerEntry, error)
+
+	CreateReferralReward(ctx context.Context, reward entities2.ReferralReward) (*entities2.ReferralReward, error)
+	ClaimReferrerReward(ctx context.Context, refereeID uuid.UUID) (*entities2.ReferralReward, error)
+	GetDueReferrerReward(ctx context.Context, refereeID uuid.UUID) (*entities2.ReferralReward, error)
+	ClaimRefereeReward(ctx context.Context, refereeID uuid.UUID) (int, error)
+	SetReferralRewardHeld(ctx context.Context, refereeID uuid.UUID, held bool) error
+
+	GetReferralFraudStats(ctx context.Context, refereeID, referrerID uuid.UUID, burstSince, idleBefore time.Time) (*entities2.ReferralFraudStats, error)
+	CreateReferralReview(ctx context.Context, review entities2.ReferralReview) (*entities2.ReferralReview, error)
+	GetLatestReferralReview(ctx context.Context, refereeID uuid.UUID) (*entities2.ReferralReview, error)
+	ListReferralReviews(ctx context.Context, filter entities2.ReferralReviewFilter) ([]*entities2.ReferralReview, error)
+	DecideReferralReview(ctx context.Context, decision entities2.ReferralReviewDecision) (*entities2.ReferralReview, error)
+
+	GetReferrerChain(ctx context.Context, userID uuid.UUID, maxDepth int) ([]entities2.ReferralAncestor, error)
+	ReverseReferralCommissions(ctx context.Context, reason entities2.LedgerReason, referenceID uuid.UUID) ([]entities2.ReferralCommission, error)
+	CreateReferralCommission(ctx context.Context, commission entities2.ReferralCommission) (*entities2.ReferralCommission, error)
+	GetUncommissionedTaskEntries(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities2.LedgerEntry, error)
+	GetReferralTree(ctx context.Context, userID uuid.UUID, maxDepth int) ([]entities2.ReferralTreeNode, error)
+
+	LockReferralGraph(ctx context.Context) error
+	IsReferralDescendant(ctx context.Context, userID, candidateID uuid.UUID) (bool, error)
+
+	GetReferralSummary(ctx context.Context, referrerID uuid.UUID, day, week, month time.Time) (*entities2.ReferralSummary, error)
+	GetRefereesPage(ctx context.Context, referrerID uuid.UUID, filter entities2.RefereeFilter) (*entities2.RefereePage, error)
+
+	AddLedgerEntry(ctx context.Context, entry entities2.LedgerEntry) (*entities2.LedgerEntry, error)
+	AddLedgerDebit(ctx context.Context, entry entities2.LedgerEntry) (*entities2.LedgerEntry, error)
+	GetLedgerPage(ctx context.Context, userID uuid.UUID, filter entities2.LedgerFilter) (*entities2.LedgerPage, error)
+}
+
+// TxManager выполняет функцию в транзакции, переданной через контекст
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Service struct {
-	storage   Storage
-	jwtSecret []byte
+	storage       Storage
+	txManager     TxManager
+	jwtSecret     []byte
+	defaultLocale string
+
+	importBatchSize int
+
+	transferDailyLimit    int
+	transferMinAccountAge time.Duration
+
+	expiryDays    map[entities2.LedgerReason]int
+	expiryWarning time.Duration
+
+	refereeBonus       int
+	referrerBonus      int
+	referrerAfterTasks int
+	commissionRatesBps []int // комиссии по уровням в сотых долях процента
+
+	requireOlderReferrer bool
+
+	fraudScoreThreshold int // 0 — проверка приглашений выключена
+	fraudBurstWindow    time.Duration
+	fraudBurstSignups   int
+	fraudIdleRatio      float64
+
+	leaderboardLocation *time.Location // зона, в которой начинаются сутки, неделя и месяц рейтинга
+	leaderboardTotal    cachedCount    // число участников рейтинга по балансу
+
+	webhookSecrets map[string][]byte
 }
 
-func New(storage Storage, jwtSecret string) *Service {
-	return &Service{
-		storage:   storage,
-		jwtSecret: []byte(jwtSecret),
+func New(storage Storage, txManager TxManager, jwtSecret string, opts ...Option) *Service {
+	s := &Service{
+		storage:       storage,
+		txManager:     txManager,
+		jwtSecret:     []byte(jwtSecret),
+		defaultLocale: defaultLocale,
+
+		importBatchSize: defaultImportBatchSize,
+
+		transferDailyLimit:    defaultTransferDailyLimit,
+		transferMinAccountAge: defaultTransferMinAccountAge,
+
+		expiryDays:    map[entities2.LedgerReason]int{},
+		expiryWarning: defaultExpiryWarning,
+
+		fraudBurstWindow:  defaultFraudBurstWindow,
+		fraudBurstSignups: defaultFraudBurstSignups,
+		fraudIdleRatio:    defaultFraudIdleRatio,
+
+		leaderboardLocation: time.Local,
+
+		webhookSecrets: map[string][]byte{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
@@ -0,0 +1,920 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// fakeStorage — хранилище в памяти для тестов сервиса. Методы повторяют поведение
+// запросов Storage, которое важно сервису: проверку средств при списании, FIFO партий,
+// уникальность выполнений и nonce, лимит задачи. Не реализованные методы паникуют.
+type fakeStorage struct {
+	Storage
+
+	mu         sync.Mutex
+	reserved   int                         // вызовы ReserveTaskSlot, не откатываются вместе с транзакцией
+	locks      [][]uuid.UUID               // аргументы вызовов LockUsers в порядке вызова
+	fraudStats entities.ReferralFraudStats // данные оценки для любого приглашения
+	userCounts int                         // вызовы CountUsersLeaderboard
+	fakeState
+}
+
+// fakeState — данные хранилища; копируется целиком, чтобы откатывать транзакции
+type fakeState struct {
+	users       map[uuid.UUID]entities.User
+	tasks       map[uuid.UUID]entities.Task
+	completions map[[2]uuid.UUID]uuid.UUID // (пользователь, задача) -> user_tasks.id
+	progress    map[[2]uuid.UUID]int
+	ledger      []entities.LedgerEntry
+	lots        []entities.PointsLot
+	earned      map[uuid.UUID]int
+	transfers   []entities.PointsTransfer
+	nonces      map[string]bool
+	payloads    map[uuid.UUID][]byte
+	partners    map[string]uuid.UUID // partner + "/" + external id -> пользователь
+	commissions []entities.ReferralCommission
+	batches     map[string]entities.PointsAdjustmentBatch
+	userTasks   []fakeUserTask // выполнения с начисленными за них баллами, источник истины сверки
+	reviews     []entities.ReferralReview
+}
+
+// fakeUserTask — строка user_tasks в части, нужной сверке
+type fakeUserTask struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Points      int
+	CompletedAt time.Time
+	RevokedAt   *time.Time
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{fakeState: fakeState{
+		users:       map[uuid.UUID]entities.User{},
+		tasks:       map[uuid.UUID]entities.Task{},
+		completions: map[[2]uuid.UUID]uuid.UUID{},
+		progress:    map[[2]uuid.UUID]int{},
+		earned:      map[uuid.UUID]int{},
+		nonces:      map[string]bool{},
+		payloads:    map[uuid.UUID][]byte{},
+		partners:    map[string]uuid.UUID{},
+		batches:     map[string]entities.PointsAdjustmentBatch{},
+	}}
+}
+
+func (st fakeState) clone() fakeState {
+	c := st
+	c.users = maps.Clone(st.users)
+	c.tasks = maps.Clone(st.tasks)
+	c.completions = maps.Clone(st.completions)
+	c.progress = maps.Clone(st.progress)
+	c.ledger = slices.Clone(st.ledger)
+	c.lots = slices.Clone(st.lots)
+	c.earned = maps.Clone(st.earned)
+	c.transfers = slices.Clone(st.transfers)
+	c.nonces = maps.Clone(st.nonces)
+	c.payloads = maps.Clone(st.payloads)
+	c.partners = maps.Clone(st.partners)
+	c.commissions = slices.Clone(st.commissions)
+	c.batches = maps.Clone(st.batches)
+	c.userTasks = slices.Clone(st.userTasks)
+	c.reviews = slices.Clone(st.reviews)
+	return c
+}
+
+// fakeTxManager выполняет функцию без транзакции, но при ошибке откатывает fakeStorage
+type fakeTxManager struct {
+	storage *fakeStorage
+}
+
+func (m fakeTxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.storage.mu.Lock()
+	snapshot := m.storage.clone()
+	m.storage.mu.Unlock()
+
+	if err := fn(ctx); err != nil {
+		m.storage.mu.Lock()
+		m.storage.fakeState = snapshot
+		m.storage.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// newTestService создаёт сервис поверх пустого fakeStorage
+func newTestService(opts ...Option) (*Service, *fakeStorage) {
+	st := newFakeStorage()
+	return New(st, fakeTxManager{storage: st}, "secret", opts...), st
+}
+
+// ptr возвращает указатель на значение для необязательных параметров
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// addUser добавляет пользователя, зарегистрированного давно, с нулевым балансом
+func (f *fakeStorage) addUser(referrer *uuid.UUID) uuid.UUID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := uuid.New()
+	f.users[id] = entities.User{
+		ID:         id,
+		Username:   id.String(),
+		ReferrerID: referrer,
+		CreatedAt:  time.Now().AddDate(-1, 0, 0),
+	}
+	return id
+}
+
+// addTask добавляет задачу с наградой reward
+func (f *fakeStorage) addTask(reward, target int, completionCap *int) uuid.UUID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := uuid.New()
+	f.tasks[id] = entities.Task{
+		ID:            id,
+		Code:          id.String(),
+		RewardPoints:  reward,
+		TargetCount:   target,
+		CompletionCap: completionCap,
+	}
+	return id
+}
+
+// balance возвращает users.points и проверяет, что он совпадает с суммой журнала
+func (f *fakeStorage) balance(t *testing.T, userID uuid.UUID) int {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var total int
+	for _, e := range f.ledger {
+		if e.UserID == userID {
+			total += e.Amount
+		}
+	}
+	if points := f.users[userID].Points; points != total {
+		t.Fatalf("users.points = %d, ledger total = %d", points, total)
+	}
+	return total
+}
+
+// entries возвращает записи журнала пользователя с причиной reason
+func (f *fakeStorage) entries(userID uuid.UUID, reason entities.LedgerReason) []entities.LedgerEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var found []entities.LedgerEntry
+	for _, e := range f.ledger {
+		if e.UserID == userID && e.Reason == reason {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// userLots возвращает партии пользователя в порядке создания
+func (f *fakeStorage) userLots(userID uuid.UUID) []entities.PointsLot {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var found []entities.PointsLot
+	for _, l := range f.lots {
+		if l.UserID == userID {
+			found = append(found, l)
+		}
+	}
+	return found
+}
+
+func (f *fakeStorage) GetUserByID(_ context.Context, id uuid.UUID) (*entities.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+func (f *fakeStorage) GetUserByUsername(_ context.Context, username string) (*entities.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, u := range f.users {
+		if u.Username == username {
+			return &u, nil
+		}
+	}
+	return nil, entities.ErrUserNotFound
+}
+
+func (f *fakeStorage) GetUserByReferralCode(_ context.Context, code string) (*entities.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, u := range f.users {
+		if u.ReferralCode == code {
+			return &u, nil
+		}
+	}
+	return nil, entities.ErrReferralCodeNotFound
+}
+
+// CreateUser, как и запрос, сразу записывает реферера из данных регистрации
+func (f *fakeStorage) CreateUser(_ context.Context, username, passwordHash, referralCode string, signup entities.SignupInfo) (*entities.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, u := range f.users {
+		if u.ReferralCode == referralCode {
+			return nil, entities.ErrReferralCodeTaken
+		}
+	}
+
+	u := entities.User{
+		ID:           uuid.New(),
+		Username:     username,
+		Password:     passwordHash,
+		ReferrerID:   signup.ReferrerID,
+		ReferralCode: referralCode,
+		Role:         entities.RoleUser,
+		CreatedAt:    time.Now(),
+	}
+	f.users[u.ID] = u
+	return &u, nil
+}
+
+func (f *fakeStorage) IsUserExists(_ context.Context, id uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.users[id]
+	return ok, nil
+}
+
+func (f *fakeStorage) LockUsers(_ context.Context, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.locks = append(f.locks, slices.Clone(ids))
+
+	var locked []uuid.UUID
+	for _, id := range ids {
+		if _, ok := f.users[id]; ok && !slices.Contains(locked, id) {
+			locked = append(locked, id)
+		}
+	}
+	sort.Slice(locked, func(i, j int) bool { return bytes.Compare(locked[i][:], locked[j][:]) < 0 })
+	return locked, nil
+}
+
+func (f *fakeStorage) GetTaskByID(_ context.Context, id uuid.UUID) (*entities.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t, ok := f.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+func (f *fakeStorage) GetTaskByCode(_ context.Context, code string) (*entities.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, t := range f.tasks {
+		if t.Code == code {
+			return &t, nil
+		}
+	}
+	return nil, entities.ErrTaskNotFound
+}
+
+func (f *fakeStorage) GetTasksByProgressEvent(_ context.Context, event entities.ProgressEvent) ([]*entities.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var tasks []*entities.Task
+	for _, t := range f.tasks {
+		if t.ProgressEvent == event {
+			tasks = append(tasks, &t)
+		}
+	}
+	return tasks, nil
+}
+
+func (f *fakeStorage) GetActiveMultiplierEvent(context.Context, uuid.UUID, time.Time) (*entities.MultiplierEvent, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) IsTaskCompleted(_ context.Context, userID, taskID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.completions[[2]uuid.UUID{userID, taskID}]
+	return ok, nil
+}
+
+func (f *fakeStorage) IncrementTaskProgress(_ context.Context, userID, taskID uuid.UUID, delta, target int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := [2]uuid.UUID{userID, taskID}
+	f.progress[key] = min(f.progress[key]+delta, target)
+	return f.progress[key], nil
+}
+
+func (f *fakeStorage) ReserveTaskSlot(_ context.Context, taskID uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reserved++
+	t := f.tasks[taskID]
+	if t.CompletionCap == nil || t.Completions >= *t.CompletionCap {
+		return entities.ErrTaskCapReached
+	}
+	t.Completions++
+	f.tasks[taskID] = t
+	return nil
+}
+
+func (f *fakeStorage) MarkTaskCompleted(_ context.Context, userID, taskID uuid.UUID, reward entities.TaskReward) (uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := [2]uuid.UUID{userID, taskID}
+	if _, ok := f.completions[key]; ok {
+		return uuid.Nil, entities.ErrTaskAlreadyCompleted
+	}
+	id := uuid.New()
+	f.completions[key] = id
+	f.userTasks = append(f.userTasks, fakeUserTask{ID: id, UserID: userID, Points: reward.Points, CompletedAt: time.Now()})
+	return id, nil
+}
+
+func (f *fakeStorage) MarkTaskMetadata(context.Context, uuid.UUID, map[string]string) error {
+	return nil
+}
+
+func (f *fakeStorage) GetDueReferrerReward(context.Context, uuid.UUID) (*entities.ReferralReward, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) GetPendingBadges(context.Context, uuid.UUID, entities.BadgeEvent) ([]*entities.Badge, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) AddLedgerEntry(_ context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	return f.addLedgerEntry(entry, false)
+}
+
+func (f *fakeStorage) AddLedgerDebit(_ context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	return f.addLedgerEntry(entry, true)
+}
+
+func (f *fakeStorage) addLedgerEntry(entry entities.LedgerEntry, requireFunds bool) (*entities.LedgerEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.users[entry.UserID]
+	if !ok {
+		return nil, entities.ErrUserNotFound
+	}
+	if requireFunds && u.Points+entry.Amount < 0 {
+		return nil, entities.ErrInsufficientPoints
+	}
+
+	u.Points += entry.Amount
+	f.users[u.ID] = u
+
+	entry.ID = uuid.New()
+	entry.BalanceAfter = u.Points
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	f.ledger = append(f.ledger, entry)
+	return &entry, nil
+}
+
+func (f *fakeStorage) AddEarnedPoints(_ context.Context, userID uuid.UUID, _ time.Time, amount int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.earned[userID] += amount
+	return nil
+}
+
+func (f *fakeStorage) CreatePointsLot(_ context.Context, lot entities.PointsLot) (*entities.PointsLot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lot.ID = uuid.New()
+	lot.CreatedAt = time.Now()
+	f.lots = append(f.lots, lot)
+	return &lot, nil
+}
+
+// ConsumePointsLots, как и запрос, расходует остатки начиная с самых старых партий
+func (f *fakeStorage) ConsumePointsLots(_ context.Context, userID uuid.UUID, amount int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.lots {
+		if amount == 0 {
+			break
+		}
+		if f.lots[i].UserID != userID || f.lots[i].Remaining == 0 {
+			continue
+		}
+		spent := min(f.lots[i].Remaining, amount)
+		f.lots[i].Remaining -= spent
+		amount -= spent
+	}
+	return nil
+}
+
+func (f *fakeStorage) GetUsersWithExpiredLots(_ context.Context, at time.Time, limit int) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var ids []uuid.UUID
+	for _, l := range f.lots {
+		if l.Remaining > 0 && l.ExpiresAt != nil && !l.ExpiresAt.After(at) && !slices.Contains(ids, l.UserID) {
+			ids = append(ids, l.UserID)
+		}
+	}
+	return ids[:min(len(ids), limit)], nil
+}
+
+func (f *fakeStorage) ExpireUserLots(_ context.Context, userID uuid.UUID, at time.Time) ([]entities.PointsLot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var expired []entities.PointsLot
+	for i, l := range f.lots {
+		if l.UserID == userID && l.Remaining > 0 && l.ExpiresAt != nil && !l.ExpiresAt.After(at) {
+			expired = append(expired, l)
+			f.lots[i].Remaining = 0
+		}
+	}
+	return expired, nil
+}
+
+func (f *fakeStorage) IsUserRegisteredBefore(_ context.Context, userID uuid.UUID, at time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.users[userID].CreatedAt.Before(at), nil
+}
+
+func (f *fakeStorage) SumUserTransfersSince(_ context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var sum int
+	for _, tr := range f.transfers {
+		if tr.SenderID == userID && !tr.CreatedAt.Before(since) {
+			sum += tr.Amount
+		}
+	}
+	return sum, nil
+}
+
+func (f *fakeStorage) CreateTransfer(_ context.Context, transfer entities.PointsTransfer) (*entities.PointsTransfer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	transfer.CreatedAt = time.Now()
+	f.transfers = append(f.transfers, transfer)
+	return &transfer, nil
+}
+
+func (f *fakeStorage) GetReferrerChain(_ context.Context, userID uuid.UUID, maxDepth int) ([]entities.ReferralAncestor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var chain []entities.ReferralAncestor
+	seen := map[uuid.UUID]bool{userID: true}
+	for current := f.users[userID].ReferrerID; current != nil && len(chain) < maxDepth && !seen[*current]; current = f.users[*current].ReferrerID {
+		seen[*current] = true
+		chain = append(chain, entities.ReferralAncestor{UserID: *current, Level: len(chain) + 1})
+	}
+	return chain, nil
+}
+
+func (f *fakeStorage) CreateReferralCommission(_ context.Context, commission entities.ReferralCommission) (*entities.ReferralCommission, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.commissions = append(f.commissions, commission)
+	return &commission, nil
+}
+
+// GetUncommissionedTaskEntries, как и запрос, пропускает отозванные выполнения
+// и начисления, с которых уже записана комиссия
+func (f *fakeStorage) GetUncommissionedTaskEntries(_ context.Context, userID uuid.UUID, since time.Time) ([]entities.LedgerEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	active := map[uuid.UUID]bool{}
+	for _, ut := range f.userTasks {
+		if ut.RevokedAt == nil {
+			active[ut.ID] = true
+		}
+	}
+	commissioned := map[uuid.UUID]bool{}
+	for _, c := range f.commissions {
+		commissioned[c.SourceEntryID] = true
+	}
+
+	var entries []entities.LedgerEntry
+	for _, e := range f.ledger {
+		if e.UserID != userID || e.Reason != entities.LedgerReasonTaskCompleted || e.Amount <= 0 ||
+			e.CreatedAt.Before(since) || e.ReferenceID == nil || !active[*e.ReferenceID] || commissioned[e.ID] {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ClaimRefereeReward и ClaimReferrerReward ничего не начисляют: бонусы за приглашение
+// в тестах выключены, и строк referral_rewards нет
+func (f *fakeStorage) ClaimRefereeReward(context.Context, uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (f *fakeStorage) ClaimReferrerReward(context.Context, uuid.UUID) (*entities.ReferralReward, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) SetReferralRewardHeld(context.Context, uuid.UUID, bool) error {
+	return nil
+}
+
+func (f *fakeStorage) GetReferralFraudStats(context.Context, uuid.UUID, uuid.UUID, time.Time, time.Time) (*entities.ReferralFraudStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := f.fraudStats
+	return &stats, nil
+}
+
+func (f *fakeStorage) CreateReferralReview(_ context.Context, review entities.ReferralReview) (*entities.ReferralReview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	review.ID = uuid.New()
+	review.Status = entities.ReferralReviewStatusPending
+	review.CreatedAt = time.Now()
+	f.reviews = append(f.reviews, review)
+	return &review, nil
+}
+
+func (f *fakeStorage) GetLatestReferralReview(_ context.Context, refereeID uuid.UUID) (*entities.ReferralReview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := len(f.reviews) - 1; i >= 0; i-- {
+		if f.reviews[i].RefereeID == refereeID {
+			review := f.reviews[i]
+			return &review, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeStorage) DecideReferralReview(_ context.Context, decision entities.ReferralReviewDecision) (*entities.ReferralReview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.reviews {
+		if f.reviews[i].ID != decision.ReviewID {
+			continue
+		}
+		if f.reviews[i].Status != entities.ReferralReviewStatusPending {
+			return nil, entities.ErrReferralReviewNotPending
+		}
+		now := time.Now()
+		f.reviews[i].Status = decision.Status
+		f.reviews[i].ReviewedAt = &now
+		review := f.reviews[i]
+		return &review, nil
+	}
+	return nil, entities.ErrReferralReviewNotFound
+}
+
+// linkPartnerUser связывает пользователя партнёра с userID
+func (f *fakeStorage) linkPartnerUser(partner, externalUserID string, userID uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.partners[partner+"/"+externalUserID] = userID
+}
+
+func (f *fakeStorage) GetPartnerUserID(_ context.Context, partner, externalUserID string) (uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	userID, ok := f.partners[partner+"/"+externalUserID]
+	if !ok {
+		return uuid.Nil, entities.ErrPartnerUserNotFound
+	}
+	return userID, nil
+}
+
+func (f *fakeStorage) SaveWebhookNonce(_ context.Context, partner, nonce string, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := partner + "/" + nonce
+	if f.nonces[key] {
+		return entities.ErrWebhookReplay
+	}
+	f.nonces[key] = true
+	return nil
+}
+
+func (f *fakeStorage) SaveUserTaskPayload(_ context.Context, userTaskID uuid.UUID, payload []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.payloads[userTaskID] = payload
+	return nil
+}
+
+func (f *fakeStorage) CreateAdjustmentBatch(_ context.Context, key, requestHash string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.batches[key]; ok {
+		return false, nil
+	}
+	f.batches[key] = entities.PointsAdjustmentBatch{IdempotencyKey: key, RequestHash: requestHash, CreatedAt: time.Now()}
+	return true, nil
+}
+
+func (f *fakeStorage) GetAdjustmentBatch(_ context.Context, key string) (*entities.PointsAdjustmentBatch, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	batch, ok := f.batches[key]
+	if !ok {
+		return nil, nil
+	}
+	batch.Adjustments = slices.Clone(batch.Adjustments)
+	return &batch, nil
+}
+
+func (f *fakeStorage) CreateAdjustment(_ context.Context, key string, _ int, adjustment entities.PointsAdjustment) (*entities.PointsAdjustment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	adjustment.CreatedAt = time.Now()
+	batch := f.batches[key]
+	batch.Adjustments = append(slices.Clone(batch.Adjustments), adjustment)
+	f.batches[key] = batch
+	return &adjustment, nil
+}
+
+// FindUnpostedTaskEntries, как и запрос, не проверяет события до первой проводки opening_balance
+func (f *fakeStorage) FindUnpostedTaskEntries(_ context.Context, userID *uuid.UUID) ([]entities.LedgerEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var cutoff time.Time
+	for _, l := range f.ledger {
+		if l.Reason == entities.LedgerReasonOpeningBalance && (cutoff.IsZero() || l.CreatedAt.Before(cutoff)) {
+			cutoff = l.CreatedAt
+		}
+	}
+
+	posted := func(reason entities.LedgerReason, id uuid.UUID) bool {
+		return slices.ContainsFunc(f.ledger, func(l entities.LedgerEntry) bool {
+			return l.Reason == reason && l.ReferenceID != nil && *l.ReferenceID == id
+		})
+	}
+
+	var found []entities.LedgerEntry
+	for _, ut := range f.userTasks {
+		if ut.Points == 0 || userID != nil && ut.UserID != *userID {
+			continue
+		}
+		if !ut.CompletedAt.Before(cutoff) && !posted(entities.LedgerReasonTaskCompleted, ut.ID) {
+			found = append(found, entities.LedgerEntry{
+				UserID: ut.UserID, Amount: ut.Points, Reason: entities.LedgerReasonTaskCompleted, ReferenceID: &ut.ID, CreatedAt: ut.CompletedAt,
+			})
+		}
+		if ut.RevokedAt != nil && !ut.RevokedAt.Before(cutoff) && !posted(entities.LedgerReasonTaskRevoked, ut.ID) {
+			found = append(found, entities.LedgerEntry{
+				UserID: ut.UserID, Amount: -ut.Points, Reason: entities.LedgerReasonTaskRevoked, ReferenceID: &ut.ID, CreatedAt: *ut.RevokedAt,
+			})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].CreatedAt.Before(found[j].CreatedAt) })
+	return found, nil
+}
+
+// expectedBalance повторяет expectedBalancesCTE. Вызывается под f.mu.
+func (f *fakeStorage) expectedBalance(userID uuid.UUID) (expected, ledgerTotal int) {
+	for _, ut := range f.userTasks {
+		if ut.UserID == userID && ut.RevokedAt == nil {
+			expected += ut.Points
+		}
+	}
+	for _, l := range f.ledger {
+		if l.UserID != userID {
+			continue
+		}
+		ledgerTotal += l.Amount
+		switch l.Reason {
+		case entities.LedgerReasonTaskCompleted, entities.LedgerReasonTaskRevoked,
+			entities.LedgerReasonOpeningBalance, entities.LedgerReasonReconciliation:
+		default:
+			expected += l.Amount
+		}
+	}
+	return expected, ledgerTotal
+}
+
+func (f *fakeStorage) GetDriftedBalances(context.Context) ([]entities.UserBalance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var balances []entities.UserBalance
+	for id, u := range f.users {
+		expected, ledgerTotal := f.expectedBalance(id)
+		if u.Points != expected || ledgerTotal != expected {
+			balances = append(balances, entities.UserBalance{UserID: id, Points: u.Points, LedgerTotal: ledgerTotal, Expected: expected})
+		}
+	}
+	return balances, nil
+}
+
+func (f *fakeStorage) AddBackfilledLedgerEntry(_ context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ledgerTotal := f.expectedBalance(entry.UserID)
+	entry.ID = uuid.New()
+	entry.BalanceAfter = ledgerTotal + entry.Amount
+	f.ledger = append(f.ledger, entry)
+	return &entry, nil
+}
+
+func (f *fakeStorage) AddReconciliationEntry(_ context.Context, userID uuid.UUID) (*entities.LedgerEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	expected, ledgerTotal := f.expectedBalance(userID)
+	u := f.users[userID]
+	u.Points = expected
+	f.users[userID] = u
+	if expected == ledgerTotal {
+		return nil, nil
+	}
+
+	entry := entities.LedgerEntry{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Amount:       expected - ledgerTotal,
+		Reason:       entities.LedgerReasonReconciliation,
+		BalanceAfter: expected,
+		CreatedAt:    time.Now(),
+	}
+	f.ledger = append(f.ledger, entry)
+	return &entry, nil
+}
+
+func (f *fakeStorage) LockReferralGraph(context.Context) error {
+	return nil
+}
+
+// IsReferralDescendant, как и запрос, идёт от candidateID вверх по реферерам
+func (f *fakeStorage) IsReferralDescendant(_ context.Context, userID, candidateID uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := map[uuid.UUID]bool{}
+	for current := f.users[candidateID].ReferrerID; current != nil && !seen[*current]; current = f.users[*current].ReferrerID {
+		if *current == userID {
+			return true, nil
+		}
+		seen[*current] = true
+	}
+	return false, nil
+}
+
+func (f *fakeStorage) UpdateUserReferrer(_ context.Context, userID, referrerID uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.users[userID]
+	if !ok {
+		return entities.ErrUserNotFound
+	}
+	if u.ReferrerID != nil {
+		return entities.ErrReferrerAlreadySet
+	}
+	u.ReferrerID = &referrerID
+	f.users[userID] = u
+	return nil
+}
+
+func (f *fakeStorage) CountUsersLeaderboard(context.Context) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.userCounts++
+	return len(f.users), nil
+}
+
+// leaderboard возвращает пользователей в порядке рейтинга по балансу: (points DESC, id) без мест
+func (f *fakeStorage) leaderboard() entities.UsersLeaderboard {
+	var board entities.UsersLeaderboard
+	for _, u := range f.users {
+		board = append(board, entities.UserLeader{ID: u.ID, Username: u.Username, Points: u.Points})
+	}
+	sort.Slice(board, func(i, j int) bool {
+		if board[i].Points != board[j].Points {
+			return board[i].Points > board[j].Points
+		}
+		return bytes.Compare(board[i].ID[:], board[j].ID[:]) < 0
+	})
+	return board
+}
+
+func (f *fakeStorage) rank(points int) int {
+	rank := 1
+	for _, u := range f.users {
+		if u.Points > points {
+			rank++
+		}
+	}
+	return rank
+}
+
+func (f *fakeStorage) GetUserLeaderboardPosition(_ context.Context, userID uuid.UUID) (*entities.UserLeader, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &entities.UserLeader{ID: u.ID, Username: u.Username, Points: u.Points, Rank: f.rank(u.Points)}, nil
+}
+
+func (f *fakeStorage) CountTiedAhead(_ context.Context, userID uuid.UUID, points int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var count int
+	for _, u := range f.users {
+		if u.Points == points && bytes.Compare(u.ID[:], userID[:]) < 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeStorage) GetLeaderboardRank(_ context.Context, points int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rank(points), nil
+}
+
+func (f *fakeStorage) GetLeaderboardAbove(_ context.Context, target entities.UserLeader, limit int) (entities.UsersLeaderboard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	board := f.leaderboard()
+	i := slices.IndexFunc(board, func(l entities.UserLeader) bool { return l.ID == target.ID })
+	above := slices.Clone(board[max(i-limit, 0):i])
+	slices.Reverse(above)
+	return above, nil
+}
+
+func (f *fakeStorage) GetLeaderboardBelow(_ context.Context, target entities.UserLeader, limit int) (entities.UsersLeaderboard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	board := f.leaderboard()
+	i := slices.IndexFunc(board, func(l entities.UserLeader) bool { return l.ID == target.ID })
+	return slices.Clone(board[i+1 : min(i+1+limit, len(board))]), nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// importRow — проверенная строка импорта, готовая к применению
+type importRow struct {
+	index int
+	user  *entities.User
+	task  *entities.Task
+	meta  map[string]string
+}
+
+// ImportTaskCompletions засчитывает выполнения из файла импорта.
+// Сначала проверяются все строки; если хотя бы одна не прошла проверку или
+// включён dry-run, ничего не применяется. Затем строки применяются пачками,
+// каждая пачка — в своей транзакции, по тем же правилам, что и CompleteTask.
+// Ошибка хранилища прерывает импорт; уже зафиксированные пачки остаются в силе.
+// Уже выполненные задания пропускаются, поэтому импорт можно безопасно повторить.
+func (s *Service) ImportTaskCompletions(ctx context.Context, rows []entities.TaskImportRow, dryRun bool) (*entities.TaskImportReport, error) {
+	report := &entities.TaskImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]entities.TaskImportRowResult, len(rows)),
+	}
+
+	valid, err := s.validateImportRows(ctx, rows, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun || report.Failed > 0 {
+		return report, nil
+	}
+
+	for start := 0; start < len(valid); start += s.importBatchSize {
+		batch := valid[start:min(start+s.importBatchSize, len(valid))]
+		if err := s.applyImportBatch(ctx, batch, report); err != nil {
+			return nil, err
+		}
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+// validateImportRows проверяет строки и заполняет отчёт.
+// Пользователи и задачи кешируются, так как в файле они обычно повторяются.
+func (s *Service) validateImportRows(ctx context.Context, rows []entities.TaskImportRow, report *entities.TaskImportReport) ([]importRow, error) {
+	var (
+		usersByID   = make(map[uuid.UUID]*entities.User)
+		usersByName = make(map[string]*entities.User)
+		tasks       = make(map[string]*entities.Task)
+		seen        = make(map[[2]uuid.UUID]struct{})
+		planned     = make(map[uuid.UUID]int)
+		valid       []importRow
+	)
+
+	for i, row := range rows {
+		result := &report.Rows[i]
+		result.Line = row.Line
+
+		fail := func(err error) {
+			result.Status = entities.TaskImportStatusFailed
+			result.Error = err.Error()
+			report.Failed++
+		}
+
+		if row.UserID == nil && row.Username == "" {
+			fail(entities.ErrImportUserRequired)
+			continue
+		}
+		if row.TaskCode == "" {
+			fail(entities.ErrImportTaskCodeRequired)
+			continue
+		}
+
+		user, err := s.resolveImportUser(ctx, row, usersByID, usersByName)
+		if err != nil {
+			if !isImportRowError(err) {
+				return nil, err
+			}
+			fail(err)
+			continue
+		}
+		result.UserID = &user.ID
+
+		task, ok := tasks[row.TaskCode]
+		if !ok {
+			task, err = s.storage.GetTaskByCode(ctx, row.TaskCode)
+			if err != nil && !errors.Is(err, entities.ErrTaskNotFound) {
+				return nil, err
+			}
+			tasks[row.TaskCode] = task
+		}
+		if task == nil {
+			fail(entities.ErrTaskNotFound)
+			continue
+		}
+		result.TaskID = &task.ID
+
+		// задачи со счётчиком выполняются только накоплением прогресса
+		if task.IsProgressBased() {
+			fail(entities.ErrTaskProgressRequired)
+			continue
+		}
+
+		key := [2]uuid.UUID{user.ID, task.ID}
+		if _, ok := seen[key]; ok {
+			result.Status = entities.TaskImportStatusSkipped
+			result.Error = entities.ErrImportDuplicateRow.Error()
+			report.Skipped++
+			continue
+		}
+		seen[key] = struct{}{}
+
+		completed, err := s.storage.IsTaskCompleted(ctx, user.ID, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if completed {
+			result.Status = entities.TaskImportStatusSkipped
+			result.Error = entities.ErrTaskAlreadyCompleted.Error()
+			report.Skipped++
+			continue
+		}
+
+		// лимит проверяется заранее, окончательно его обеспечивает хранилище
+		if remaining := task.RemainingSlots(); remaining != nil && planned[task.ID] >= *remaining {
+			fail(entities.ErrTaskCapReached)
+			continue
+		}
+		planned[task.ID]++
+
+		result.Status = entities.TaskImportStatusValid
+		report.Valid++
+		valid = append(valid, importRow{index: i, user: user, task: task, meta: importMetadata(row)})
+	}
+
+	return valid, nil
+}
+
+// resolveImportUser находит пользователя строки по user_id или username.
+// Если заданы оба, они должны указывать на одного пользователя.
+func (s *Service) resolveImportUser(
+	ctx context.Context,
+	row entities.TaskImportRow,
+	byID map[uuid.UUID]*entities.User,
+	byName map[string]*entities.User,
+) (*entities.User, error) {
+	var err error
+
+	if row.UserID != nil {
+		user, ok := byID[*row.UserID]
+		if !ok {
+			user, err = s.storage.GetUserByID(ctx, *row.UserID)
+			if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+				return nil, err
+			}
+			byID[*row.UserID] = user
+		}
+		if user == nil {
+			return nil, entities.ErrUserNotFound
+		}
+		if row.Username != "" && row.Username != user.Username {
+			return nil, entities.ErrImportUserMismatch
+		}
+		return user, nil
+	}
+
+	user, ok := byName[row.Username]
+	if !ok {
+		user, err = s.storage.GetUserByUsername(ctx, row.Username)
+		if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+			return nil, err
+		}
+		byName[row.Username] = user
+	}
+	if user == nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// applyImportBatch применяет пачку строк в одной транзакции.
+// Каждая строка выполняется во вложенной транзакции (savepoint), поэтому гонка
+// с другим источником выполнения или исчерпанный лимит откатывают только её.
+// Размер пачки ограничивает время, на которое транзакция держит блокировки.
+func (s *Service) applyImportBatch(ctx context.Context, batch []importRow, report *entities.TaskImportReport) error {
+	type outcome struct {
+		userTaskID uuid.UUID
+		err        error
+	}
+	outcomes := make([]outcome, len(batch))
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		for i, row := range batch {
+			var userTaskID uuid.UUID
+			err := s.txManager.Do(ctx, func(ctx context.Context) error {
+				var err error
+				userTaskID, err = s.completeTask(ctx, row.user, row.task, row.meta)
+				return err
+			})
+			if err != nil && !isImportApplyError(err) {
+				return err
+			}
+			outcomes[i] = outcome{userTaskID: userTaskID, err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// отчёт обновляем только после фиксации транзакции
+	for i, row := range batch {
+		result := &report.Rows[row.index]
+		report.Valid--
+		switch {
+		case errors.Is(outcomes[i].err, entities.ErrTaskAlreadyCompleted):
+			result.Status = entities.TaskImportStatusSkipped
+			result.Error = outcomes[i].err.Error()
+			report.Skipped++
+		case outcomes[i].err != nil:
+			result.Status = entities.TaskImportStatusFailed
+			result.Error = outcomes[i].err.Error()
+			report.Failed++
+		default:
+			result.Status = entities.TaskImportStatusCompleted
+			result.UserTaskID = &outcomes[i].userTaskID
+			report.Completed++
+		}
+	}
+
+	return nil
+}
+
+// importMetadata дополняет метадату строки признаком источника
+func importMetadata(row entities.TaskImportRow) map[string]string {
+	meta := make(map[string]string, len(row.Metadata)+1)
+	for k, v := range row.Metadata {
+		meta[k] = v
+	}
+	meta["source"] = "import"
+	return meta
+}
+
+// isImportRowError сообщает, относится ли ошибка к содержимому строки, а не к хранилищу
+func isImportRowError(err error) bool {
+	return errors.Is(err, entities.ErrUserNotFound) || errors.Is(err, entities.ErrImportUserMismatch)
+}
+
+// isImportApplyError сообщает, относится ли ошибка применения к строке,
+// а не к хранилищу: такие строки попадают в отчёт, а пачка продолжается
+func isImportApplyError(err error) bool {
+	return errors.Is(err, entities.ErrTaskAlreadyCompleted) || errors.Is(err, entities.ErrTaskCapReached)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// racingStorage засчитывает выполнение raced другим источником прямо перед вставкой импорта
+type racingStorage struct {
+	*fakeStorage
+	raced uuid.UUID
+}
+
+func (r racingStorage) MarkTaskCompleted(ctx context.Context, userID, taskID uuid.UUID, reward entities.TaskReward) (uuid.UUID, error) {
+	if userID == r.raced {
+		if _, err := r.fakeStorage.MarkTaskCompleted(ctx, userID, taskID, reward); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return r.fakeStorage.MarkTaskCompleted(ctx, userID, taskID, reward)
+}
+
+func TestImportTaskCompletionsAppliesBatchesAndSkipsRacedRow(t *testing.T) {
+	ctx := context.Background()
+	st := newFakeStorage()
+	taskID := st.addTask(10, 1, nil)
+	users := []uuid.UUID{st.addUser(nil), st.addUser(nil), st.addUser(nil)}
+	s := New(racingStorage{fakeStorage: st, raced: users[1]}, fakeTxManager{storage: st}, "secret", WithImportBatchSize(2))
+
+	rows := make([]entities.TaskImportRow, len(users))
+	for i := range users {
+		rows[i] = entities.TaskImportRow{Line: i + 1, UserID: &users[i], TaskCode: taskID.String()}
+	}
+
+	report, err := s.ImportTaskCompletions(ctx, rows, false)
+	if err != nil {
+		t.Fatalf("ImportTaskCompletions() error = %v", err)
+	}
+	if !report.Applied || report.Completed != 2 || report.Skipped != 1 || report.Failed != 0 || report.Valid != 0 {
+		t.Fatalf("report = %+v, want 2 completed and 1 skipped", report)
+	}
+
+	want := []entities.TaskImportStatus{
+		entities.TaskImportStatusCompleted,
+		entities.TaskImportStatusSkipped,
+		entities.TaskImportStatusCompleted,
+	}
+	for i, row := range report.Rows {
+		if row.Status != want[i] {
+			t.Fatalf("row %d status = %s, want %s", i, row.Status, want[i])
+		}
+	}
+
+	// откат строки в пачке не затрагивает начисления соседних строк
+	for i, want := range []int{10, 0, 10} {
+		if got := st.balance(t, users[i]); got != want {
+			t.Fatalf("balance of user %d = %d, want %d", i, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"service-boilerplate-go/internal/service/entities"
 
@@ -10,9 +11,220 @@ import (
 )
 
 func (s *Service) CompleteTask(ctx context.Context, userID, taskID uuid.UUID, metadata map[string]string) error {
+	user, task, err := s.getUserAndTask(ctx, userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := s.completeTask(ctx, user, task, metadata)
+		return err
+	})
+}
+
+// completeTask проверяет правила выполнения, начисляет награду и сохраняет метадату.
+// Общая часть для всех источников выполнения. Должна вызываться внутри транзакции.
+func (s *Service) completeTask(ctx context.Context, user *entities.User, task *entities.Task, metadata map[string]string) (uuid.UUID, error) {
+	// задачи со счётчиком выполняются только накоплением прогресса
+	if task.IsProgressBased() {
+		return uuid.Nil, entities.ErrTaskProgressRequired
+	}
+
+	// проверяем, не было ли задание уже выполнено
+	completed, err := s.storage.IsTaskCompleted(ctx, user.ID, task.ID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if completed {
+		return uuid.Nil, entities.ErrTaskAlreadyCompleted
+	}
+
+	reward, err := s.rewardForTask(ctx, task)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := s.lockReferralChains(ctx, user.ID); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := s.reserveTaskSlot(ctx, task); err != nil {
+		return uuid.Nil, err
+	}
+
+	// сохраняем выполненное задание
+	userTaskID, err := s.storage.MarkTaskCompleted(ctx, user.ID, task.ID, reward)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := s.postLedgerEntry(ctx, user.ID, reward.Points, entities.LedgerReasonTaskCompleted, &userTaskID); err != nil {
+		return uuid.Nil, err
+	}
+
+	// сохраняем метадату батчем, если она есть
+	if len(metadata) > 0 {
+		if err := s.storage.MarkTaskMetadata(ctx, userTaskID, metadata); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if err := s.handleBadgeEvents(ctx, user.ID, entities.BadgeEventTaskCompleted, entities.BadgeEventPointsChanged); err != nil {
+		return uuid.Nil, err
+	}
+
+	// выполнение может открыть отложенный бонус рефереру
+	if err := s.payReferrerReward(ctx, user.ID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userTaskID, nil
+}
+
+// IncrementTaskProgress увеличивает прогресс по задаче со счётчиком.
+// При достижении цели задача выполняется и баллы начисляются один раз.
+func (s *Service) IncrementTaskProgress(ctx context.Context, userID, taskID uuid.UUID, increment int) (*entities.TaskProgress, error) {
+	user, task, err := s.getUserAndTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !task.IsProgressBased() {
+		return nil, entities.ErrTaskNotProgressBased
+	}
+	// прогресс таких задач двигают только внутренние события
+	if task.ProgressEvent != "" {
+		return nil, entities.ErrTaskProgressManaged
+	}
+
+	var progress *entities.TaskProgress
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		completed, err := s.storage.IsTaskCompleted(ctx, user.ID, task.ID)
+		if err != nil {
+			return err
+		}
+		if completed {
+			return entities.ErrTaskAlreadyCompleted
+		}
+
+		progress, err = s.advanceTaskProgress(ctx, user.ID, task, increment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// reserveTaskSlot занимает слот лимита задачи, если он задан. Строки пользователя
+// и его рефереров к этому моменту уже заблокированы через lockReferralChains, то есть
+// раньше строки задачи, поэтому выполнения не попадают во взаимную блокировку
+// с другими транзакциями. Выполнения задач без лимита строку задачи не трогают
+// и идут параллельно. Должна вызываться внутри транзакции.
+func (s *Service) reserveTaskSlot(ctx context.Context, task *entities.Task) error {
+	if task.CompletionCap == nil {
+		return nil
+	}
+
+	return s.storage.ReserveTaskSlot(ctx, task.ID)
+}
+
+// advanceTaskProgress двигает прогресс и выполняет задачу при достижении цели.
+// Должна вызываться внутри транзакции.
+func (s *Service) advanceTaskProgress(ctx context.Context, userID uuid.UUID, task *entities.Task, increment int) (*entities.TaskProgress, error) {
+	current, err := s.storage.IncrementTaskProgress(ctx, userID, task.ID, increment, task.TargetCount)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &entities.TaskProgress{
+		TaskID:      task.ID,
+		Code:        task.Code,
+		Title:       task.Title,
+		Description: task.Description,
+		Progress:    current,
+		Target:      task.TargetCount,
+	}
+
+	if current < task.TargetCount {
+		return progress, nil
+	}
+
+	reward, err := s.rewardForTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.lockReferralChains(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.reserveTaskSlot(ctx, task); err != nil {
+		return nil, err
+	}
+
+	// уникальный индекс user_tasks гарантирует однократное начисление
+	userTaskID, err := s.storage.MarkTaskCompleted(ctx, userID, task.ID, reward)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.postLedgerEntry(ctx, userID, reward.Points, entities.LedgerReasonTaskCompleted, &userTaskID); err != nil {
+		return nil, err
+	}
+	progress.Completed = true
+
+	if err := s.handleBadgeEvents(ctx, userID, entities.BadgeEventTaskCompleted, entities.BadgeEventPointsChanged); err != nil {
+		return nil, err
+	}
+
+	if err := s.payReferrerReward(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// rewardForTask рассчитывает начисление за задачу с учётом действующего события-множителя
+func (s *Service) rewardForTask(ctx context.Context, task *entities.Task) (entities.TaskReward, error) {
+	event, err := s.storage.GetActiveMultiplierEvent(ctx, task.ID, time.Now())
+	if err != nil {
+		return entities.TaskReward{}, err
+	}
+	return entities.NewTaskReward(task, event), nil
+}
+
+// handleProgressEvent двигает прогресс всех задач, подписанных на событие.
+// Уже выполненные задачи пропускаются. Должна вызываться внутри транзакции.
+func (s *Service) handleProgressEvent(ctx context.Context, userID uuid.UUID, event entities.ProgressEvent) error {
+	tasks, err := s.storage.GetTasksByProgressEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		completed, err := s.storage.IsTaskCompleted(ctx, userID, task.ID)
+		if err != nil {
+			return err
+		}
+		if completed {
+			continue
+		}
+
+		if _, err := s.advanceTaskProgress(ctx, userID, task, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getUserAndTask параллельно загружает пользователя и задачу
+func (s *Service) getUserAndTask(ctx context.Context, userID, taskID uuid.UUID) (*entities.User, *entities.Task, error) {
 	var (
-		user       *entities.User
-		taskExists bool
+		user *entities.User
+		task *entities.Task
 	)
 
 	g, groupCtx := errgroup.WithContext(ctx)
@@ -31,54 +243,32 @@ func (s *Service) CompleteTask(ctx context.Context, userID, taskID uuid.UUID, me
 	})
 
 	g.Go(func() error {
-		exists, err := s.storage.IsTaskExists(groupCtx, taskID)
+		t, err := s.storage.GetTaskByID(groupCtx, taskID)
 		if err != nil {
 			return err
 		}
-		if !exists {
+		if t == nil {
 			return entities.ErrTaskNotFound
 		}
-		taskExists = true
+		task = t
 		return nil
 	})
 
 	if err := g.Wait(); err != nil {
-		return err
-	}
-
-	if user == nil {
-		return entities.ErrUserNotFound
-	}
-	if !taskExists {
-		return entities.ErrTaskNotFound
+		return nil, nil, err
 	}
 
-	// проверяем, не было ли задание уже выполнено
-	completed, err := s.storage.IsTaskCompleted(ctx, userID, taskID)
-	if err != nil {
-		return err
-	}
-	if completed {
-		return entities.ErrTaskAlreadyCompleted
-	}
-
-	// сохраняем выполненное задание
-	userTaskID, err := s.storage.MarkTaskCompleted(ctx, userID, taskID)
-	if err != nil {
-		return err
-	}
-
-	// сохраняем метадату батчем, если она есть
-	if len(metadata) > 0 {
-		if err := s.storage.MarkTaskMetadata(ctx, userTaskID, metadata); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return user, task, nil
 }
 
+// InputReferrer устанавливает пользователю реферера.
+// Граф рефералов остаётся деревом: нельзя указать себя или своего приглашённого,
+// а при включённой проверке — и пользователя, зарегистрированного позже.
 func (s *Service) InputReferrer(ctx context.Context, userID, referrerID uuid.UUID) error {
+	if userID == referrerID {
+		return entities.ErrSelfReferral
+	}
+
 	var (
 		user           *entities.User
 		userExists     bool
@@ -126,10 +316,60 @@ func (s *Service) InputReferrer(ctx context.Context, userID, referrerID uuid.UUI
 		return entities.ErrReferrerAlreadySet
 	}
 
-	// обновляем поле referrer_id
-	if err := s.storage.UpdateUserReferrer(ctx, userID, referrerID); err != nil {
+	if s.requireOlderReferrer {
+		older, err := s.storage.IsUserRegisteredBefore(ctx, referrerID, user.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if !older {
+			return entities.ErrReferrerNotOlder
+		}
+	}
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		// проверка цикла и установка реферера выполняются под одной блокировкой графа
+		if err := s.storage.LockReferralGraph(ctx); err != nil {
+			return err
+		}
+
+		descendant, err := s.storage.IsReferralDescendant(ctx, userID, referrerID)
+		if err != nil {
+			return err
+		}
+		if descendant {
+			return entities.ErrReferralCycle
+		}
+
+		// бонусы и прогресс реферера затрагивают его цепочку, блокируем её заранее
+		if err := s.lockReferralChains(ctx, userID, referrerID); err != nil {
+			return err
+		}
+
+		// обновляем поле referrer_id
+		if err := s.storage.UpdateUserReferrer(ctx, userID, referrerID); err != nil {
+			return err
+		}
+
+		return s.handleReferrerSet(ctx, userID, referrerID)
+	})
+}
+
+// handleReferrerSet начисляет бонусы за приглашение и засчитывает его рефереру.
+// Приглашение на проверке засчитывается рефереру только после одобрения.
+// Должна вызываться в транзакции, в которой устанавливается referrer_id.
+func (s *Service) handleReferrerSet(ctx context.Context, userID, referrerID uuid.UUID) error {
+	// бонусы начисляются в той же транзакции, что и установка реферера
+	held, err := s.grantReferralRewards(ctx, userID, referrerID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	// засчитываем рефереру приглашение в задачах со счётчиком
+	if !held {
+		if err := s.handleProgressEvent(ctx, referrerID, entities.ProgressEventReferralJoined); err != nil {
+			return err
+		}
+	}
+
+	return s.handleBadgeEvents(ctx, referrerID, entities.BadgeEventReferrerSet)
 }
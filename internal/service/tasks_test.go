@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestIncrementTaskProgressCompletesOnce(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	userID := st.addUser(nil)
+	taskID := st.addTask(30, 3, nil)
+
+	progress, err := s.IncrementTaskProgress(ctx, userID, taskID, 2)
+	if err != nil {
+		t.Fatalf("IncrementTaskProgress() error = %v", err)
+	}
+	if progress.Completed || progress.Progress != 2 {
+		t.Fatalf("progress = %d, completed = %t; want 2, false", progress.Progress, progress.Completed)
+	}
+
+	// прогресс сверх цели не копится, задача выполняется один раз
+	progress, err = s.IncrementTaskProgress(ctx, userID, taskID, 5)
+	if err != nil {
+		t.Fatalf("IncrementTaskProgress() error = %v", err)
+	}
+	if !progress.Completed || progress.Progress != 3 {
+		t.Fatalf("progress = %d, completed = %t; want 3, true", progress.Progress, progress.Completed)
+	}
+
+	if _, err := s.IncrementTaskProgress(ctx, userID, taskID, 1); !errors.Is(err, entities.ErrTaskAlreadyCompleted) {
+		t.Fatalf("IncrementTaskProgress() after completion error = %v, want %v", err, entities.ErrTaskAlreadyCompleted)
+	}
+
+	if got := len(st.entries(userID, entities.LedgerReasonTaskCompleted)); got != 1 {
+		t.Fatalf("task_completed entries = %d, want 1", got)
+	}
+	if got := st.balance(t, userID); got != 30 {
+		t.Fatalf("balance = %d, want 30", got)
+	}
+}
+
+func TestCompleteTaskRejectsProgressTask(t *testing.T) {
+	s, st := newTestService()
+	userID := st.addUser(nil)
+	taskID := st.addTask(30, 3, nil)
+
+	if err := s.CompleteTask(context.Background(), userID, taskID, nil); !errors.Is(err, entities.ErrTaskProgressRequired) {
+		t.Fatalf("CompleteTask() error = %v, want %v", err, entities.ErrTaskProgressRequired)
+	}
+}
+
+func TestCompleteTaskRespectsCompletionCap(t *testing.T) {
+	ctx := context.Background()
+	completionCap := 2
+	s, st := newTestService()
+	taskID := st.addTask(10, 1, &completionCap)
+	users := []uuid.UUID{st.addUser(nil), st.addUser(nil), st.addUser(nil)}
+
+	for _, userID := range users[:2] {
+		if err := s.CompleteTask(ctx, userID, taskID, nil); err != nil {
+			t.Fatalf("CompleteTask() error = %v", err)
+		}
+	}
+
+	if err := s.CompleteTask(ctx, users[2], taskID, nil); !errors.Is(err, entities.ErrTaskCapReached) {
+		t.Fatalf("CompleteTask() over cap error = %v, want %v", err, entities.ErrTaskCapReached)
+	}
+	if got := st.balance(t, users[2]); got != 0 {
+		t.Fatalf("balance over cap = %d, want 0", got)
+	}
+	if got := st.tasks[taskID].Completions; got != completionCap {
+		t.Fatalf("completions = %d, want %d", got, completionCap)
+	}
+
+	// повторное выполнение отклоняется до резервирования слота
+	if err := s.CompleteTask(ctx, users[0], taskID, nil); !errors.Is(err, entities.ErrTaskAlreadyCompleted) {
+		t.Fatalf("CompleteTask() repeat error = %v, want %v", err, entities.ErrTaskAlreadyCompleted)
+	}
+	if st.reserved != 3 {
+		t.Fatalf("ReserveTaskSlot calls = %d, want 3", st.reserved)
+	}
+}
+
+func TestCompleteTaskWithoutCapDoesNotReserve(t *testing.T) {
+	s, st := newTestService()
+	taskID := st.addTask(10, 1, nil)
+	userID := st.addUser(nil)
+
+	if err := s.CompleteTask(context.Background(), userID, taskID, nil); err != nil {
+		t.Fatalf("CompleteTask() error = %v", err)
+	}
+	if st.reserved != 0 {
+		t.Fatalf("ReserveTaskSlot calls = %d, want 0", st.reserved)
+	}
+	if got := st.balance(t, userID); got != 10 {
+		t.Fatalf("balance = %d, want 10", got)
+	}
+}
+
+func TestIncrementTaskProgressRespectsCompletionCap(t *testing.T) {
+	ctx := context.Background()
+	completionCap := 1
+	s, st := newTestService()
+	taskID := st.addTask(10, 2, &completionCap)
+	first, second := st.addUser(nil), st.addUser(nil)
+
+	for _, userID := range []uuid.UUID{first, second} {
+		if _, err := s.IncrementTaskProgress(ctx, userID, taskID, 1); err != nil {
+			t.Fatalf("IncrementTaskProgress() error = %v", err)
+		}
+	}
+
+	if _, err := s.IncrementTaskProgress(ctx, first, taskID, 1); err != nil {
+		t.Fatalf("IncrementTaskProgress() error = %v", err)
+	}
+	// отказ откатывает и прогресс: после освобождения слота задачу можно будет добить
+	if _, err := s.IncrementTaskProgress(ctx, second, taskID, 1); !errors.Is(err, entities.ErrTaskCapReached) {
+		t.Fatalf("IncrementTaskProgress() over cap error = %v, want %v", err, entities.ErrTaskCapReached)
+	}
+	if got := st.balance(t, second); got != 0 {
+		t.Fatalf("balance over cap = %d, want 0", got)
+	}
+}
+
+func TestInputReferrerRejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+
+	// a ← b ← c: a пригласил b, b пригласил c
+	a := st.addUser(nil)
+	b := st.addUser(&a)
+	c := st.addUser(&b)
+
+	tests := []struct {
+		name     string
+		user     uuid.UUID
+		referrer uuid.UUID
+		want     error
+	}{
+		{name: "self", user: a, referrer: a, want: entities.ErrSelfReferral},
+		{name: "direct referee", user: a, referrer: b, want: entities.ErrReferralCycle},
+		{name: "indirect referee", user: a, referrer: c, want: entities.ErrReferralCycle},
+		{name: "already set", user: c, referrer: a, want: entities.ErrReferrerAlreadySet},
+		{name: "unknown referrer", user: a, referrer: uuid.New(), want: entities.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.InputReferrer(ctx, tt.user, tt.referrer); !errors.Is(err, tt.want) {
+				t.Fatalf("InputReferrer() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if st.users[a].ReferrerID != nil {
+		t.Fatalf("referrer of a = %v, want none", st.users[a].ReferrerID)
+	}
+
+	// ветка, не ведущая к a, допустима
+	d := st.addUser(nil)
+	if err := s.InputReferrer(ctx, a, d); err != nil {
+		t.Fatalf("InputReferrer() error = %v", err)
+	}
+	if got := st.users[a].ReferrerID; got == nil || *got != d {
+		t.Fatalf("referrer of a = %v, want %s", got, d)
+	}
+}
+
+func TestInputReferrerRequiresOlderReferrer(t *testing.T) {
+	s, st := newTestService(WithOlderReferrerRequired(true))
+	userID, referrerID := st.addUser(nil), st.addUser(nil)
+
+	u := st.users[referrerID]
+	u.CreatedAt = time.Now()
+	st.users[referrerID] = u
+
+	if err := s.InputReferrer(context.Background(), userID, referrerID); !errors.Is(err, entities.ErrReferrerNotOlder) {
+		t.Fatalf("InputReferrer() error = %v, want %v", err, entities.ErrReferrerNotOlder)
+	}
+}
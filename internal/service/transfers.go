@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// transferLimitWindow — окно, в котором действует лимит переводов
+const transferLimitWindow = 24 * time.Hour
+
+// TransferPoints переводит баллы другому пользователю.
+// Списание и зачисление выполняются в одной транзакции; строки обоих пользователей
+// блокируются заранее в порядке id, поэтому проверка лимита не гоняется с другими переводами.
+// Начисления по реферальной цепочке блокируют строки в том же порядке (см. lockReferralChains),
+// поэтому перевод между реферером и приглашённым не блокируется взаимно с выполнением задачи.
+func (s *Service) TransferPoints(ctx context.Context, transfer entities.PointsTransfer) (*entities.PointsTransfer, error) {
+	if transfer.SenderID == transfer.RecipientID {
+		return nil, entities.ErrTransferToSelf
+	}
+
+	var created *entities.PointsTransfer
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		locked, err := s.storage.LockUsers(ctx, transfer.SenderID, transfer.RecipientID)
+		if err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return entities.ErrUserNotFound
+		}
+
+		now := time.Now()
+
+		oldEnough, err := s.storage.IsUserRegisteredBefore(ctx, transfer.SenderID, now.Add(-s.transferMinAccountAge))
+		if err != nil {
+			return err
+		}
+		if !oldEnough {
+			return entities.ErrAccountTooNew
+		}
+
+		sent, err := s.storage.SumUserTransfersSince(ctx, transfer.SenderID, now.Add(-transferLimitWindow))
+		if err != nil {
+			return err
+		}
+		if sent+transfer.Amount > s.transferDailyLimit {
+			return entities.ErrTransferLimitExceeded
+		}
+
+		transfer.ID = uuid.New()
+
+		if err := s.spendPoints(ctx, transfer.SenderID, transfer.Amount, entities.LedgerReasonTransferOut, &transfer.ID); err != nil {
+			return err
+		}
+		if err := s.postLedgerEntry(ctx, transfer.RecipientID, transfer.Amount, entities.LedgerReasonTransferIn, &transfer.ID); err != nil {
+			return err
+		}
+
+		created, err = s.storage.CreateTransfer(ctx, transfer)
+		if err != nil {
+			return err
+		}
+
+		return s.handleBadgeEvents(ctx, transfer.RecipientID, entities.BadgeEventPointsChanged)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestTransferPointsDailyLimit(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithTransferLimits(ptr(100), ptr(time.Hour)))
+	sender, recipient := st.addUser(nil), st.addUser(nil)
+	if err := s.postLedgerEntry(ctx, sender, 500, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	transfer := func(amount int) error {
+		_, err := s.TransferPoints(ctx, entities.PointsTransfer{SenderID: sender, RecipientID: recipient, Amount: amount})
+		return err
+	}
+
+	if err := transfer(60); err != nil {
+		t.Fatalf("TransferPoints(60) error = %v", err)
+	}
+	if err := transfer(50); !errors.Is(err, entities.ErrTransferLimitExceeded) {
+		t.Fatalf("TransferPoints(50) error = %v, want %v", err, entities.ErrTransferLimitExceeded)
+	}
+	// лимит включает уже отправленное: 60 + 40 ровно укладываются в 100
+	if err := transfer(40); err != nil {
+		t.Fatalf("TransferPoints(40) error = %v", err)
+	}
+	if err := transfer(1); !errors.Is(err, entities.ErrTransferLimitExceeded) {
+		t.Fatalf("TransferPoints(1) error = %v, want %v", err, entities.ErrTransferLimitExceeded)
+	}
+
+	if got := st.balance(t, sender); got != 400 {
+		t.Fatalf("sender balance = %d, want 400", got)
+	}
+	if got := st.balance(t, recipient); got != 100 {
+		t.Fatalf("recipient balance = %d, want 100", got)
+	}
+}
+
+func TestTransferPointsLimitWindow(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithTransferLimits(ptr(100), ptr(time.Hour)))
+	sender, recipient := st.addUser(nil), st.addUser(nil)
+	if err := s.postLedgerEntry(ctx, sender, 500, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	// перевод старше окна лимита не учитывается
+	st.transfers = append(st.transfers, entities.PointsTransfer{
+		SenderID:    sender,
+		RecipientID: recipient,
+		Amount:      100,
+		CreatedAt:   time.Now().Add(-transferLimitWindow - time.Minute),
+	})
+
+	if _, err := s.TransferPoints(ctx, entities.PointsTransfer{SenderID: sender, RecipientID: recipient, Amount: 100}); err != nil {
+		t.Fatalf("TransferPoints() error = %v", err)
+	}
+}
+
+func TestTransferPointsRejections(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService(WithTransferLimits(ptr(1000), ptr(time.Hour)))
+	sender, recipient := st.addUser(nil), st.addUser(nil)
+	if err := s.postLedgerEntry(ctx, sender, 50, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+
+	newcomer := st.addUser(nil)
+	u := st.users[newcomer]
+	u.CreatedAt = time.Now()
+	st.users[newcomer] = u
+
+	tests := []struct {
+		name     string
+		transfer entities.PointsTransfer
+		want     error
+	}{
+		{name: "to self", transfer: entities.PointsTransfer{SenderID: sender, RecipientID: sender, Amount: 10}, want: entities.ErrTransferToSelf},
+		{name: "insufficient funds", transfer: entities.PointsTransfer{SenderID: sender, RecipientID: recipient, Amount: 51}, want: entities.ErrInsufficientPoints},
+		{name: "new account", transfer: entities.PointsTransfer{SenderID: newcomer, RecipientID: recipient, Amount: 1}, want: entities.ErrAccountTooNew},
+		{name: "unknown recipient", transfer: entities.PointsTransfer{SenderID: sender, RecipientID: uuid.New(), Amount: 1}, want: entities.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.TransferPoints(ctx, tt.transfer); !errors.Is(err, tt.want) {
+				t.Fatalf("TransferPoints() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if got := st.balance(t, sender); got != 50 {
+		t.Fatalf("sender balance = %d, want 50", got)
+	}
+	if got := st.balance(t, recipient); got != 0 {
+		t.Fatalf("recipient balance = %d, want 0", got)
+	}
+	if len(st.transfers) != 0 {
+		t.Fatalf("transfers = %d, want 0", len(st.transfers))
+	}
+}
+
+func TestTransferPointsZeroLimits(t *testing.T) {
+	ctx := context.Background()
+
+	// нулевой лимит запрещает переводы, а не откатывается на значение по умолчанию
+	s, st := newTestService(WithTransferLimits(ptr(0), nil))
+	sender, recipient := st.addUser(nil), st.addUser(nil)
+	if err := s.postLedgerEntry(ctx, sender, 50, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if _, err := s.TransferPoints(ctx, entities.PointsTransfer{SenderID: sender, RecipientID: recipient, Amount: 1}); !errors.Is(err, entities.ErrTransferLimitExceeded) {
+		t.Fatalf("TransferPoints() with zero limit error = %v, want %v", err, entities.ErrTransferLimitExceeded)
+	}
+
+	// нулевой возраст аккаунта снимает ограничение для новых пользователей
+	s, st = newTestService(WithTransferLimits(nil, ptr(time.Duration(0))))
+	sender, recipient = st.addUser(nil), st.addUser(nil)
+	u := st.users[sender]
+	u.CreatedAt = time.Now()
+	st.users[sender] = u
+	if err := s.postLedgerEntry(ctx, sender, 50, entities.LedgerReasonAdminAdjustment, nil); err != nil {
+		t.Fatalf("postLedgerEntry() error = %v", err)
+	}
+	if _, err := s.TransferPoints(ctx, entities.PointsTransfer{SenderID: sender, RecipientID: recipient, Amount: 10}); err != nil {
+		t.Fatalf("TransferPoints() with zero account age error = %v", err)
+	}
+}
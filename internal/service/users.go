@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"service-boilerplate-go/internal/service/entities"
 
@@ -11,7 +12,9 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-func (s *Service) Auth(ctx context.Context, username, password string) (token string, userID uuid.UUID, err error) {
+// Auth выполняет вход пользователя, а для нового username — регистрацию.
+// Данные signup, включая реферера и атрибуцию, применяются только при регистрации.
+func (s *Service) Auth(ctx context.Context, username, password string, signup entities.SignupInfo) (token string, userID uuid.UUID, err error) {
 	user, err := s.storage.GetUserByUsername(ctx, username)
 	if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
 		return "", uuid.Nil, err
@@ -22,7 +25,7 @@ func (s *Service) Auth(ctx context.Context, username, password string) (token st
 		if err != nil {
 			return "", uuid.Nil, err
 		}
-		user, err = s.storage.CreateUser(ctx, username, hash)
+		user, err = s.registerUser(ctx, username, hash, signup)
 		if err != nil {
 			return "", uuid.Nil, err
 		}
@@ -61,10 +64,25 @@ func (s *Service) generateToken(userID uuid.UUID) (string, error) {
 	return t.SignedString(s.jwtSecret)
 }
 
-func (s *Service) GetUsersLeaderboard(ctx context.Context, limit, offset int) (entities.UsersLeaderboard, error) {
-	return s.storage.GetUsersLeaderboard(ctx, limit, offset)
+func (s *Service) GetUserStatus(ctx context.Context, userID uuid.UUID, locales []string) (*entities.UserStatus, error) {
+	status, err := s.storage.GetUserStatus(ctx, userID, s.withDefaultLocale(locales))
+	if err != nil {
+		return nil, err
+	}
+
+	status.ExpiringSoon, err = s.storage.GetExpiringPoints(ctx, userID, time.Now().Add(s.expiryWarning))
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
 }
 
-func (s *Service) GetUserStatus(ctx context.Context, userID uuid.UUID) (*entities.UserStatus, error) {
-	return s.storage.GetUserStatus(ctx, userID)
+// IsAdmin проверяет, что пользователь существует и имеет роль администратора
+func (s *Service) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	user, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin(), nil
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// webhookTolerance — допустимое расхождение времени подписи и текущего времени
+const webhookTolerance = 5 * time.Minute
+
+// webhookNonceRetention — сколько хранить nonce. Подпись с временем до webhookTolerance
+// в будущем остаётся действительной ещё две величины допуска после получения.
+const webhookNonceRetention = 2 * webhookTolerance
+
+// VerifyWebhookSignature проверяет подпись доставки партнёра.
+// Подпись — hex(HMAC-SHA256(secret, timestamp + "." + body)), допускается префикс "sha256=".
+// Timestamp — unix-время в секундах, не старше webhookTolerance.
+func (s *Service) VerifyWebhookSignature(partner, timestamp, signature string, body []byte) error {
+	secret, ok := s.webhookSecrets[partner]
+	if !ok {
+		return entities.ErrUnknownPartner
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return entities.ErrInvalidWebhookSignature
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return entities.ErrInvalidWebhookSignature
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return entities.ErrInvalidWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return entities.ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+// CompletePartnerTask засчитывает выполнение, о котором сообщил партнёр.
+// Nonce сохраняется в той же транзакции, что и выполнение, поэтому при ошибке
+// партнёр может повторить доставку с тем же nonce.
+func (s *Service) CompletePartnerTask(ctx context.Context, completion entities.PartnerTaskCompletion) error {
+	var (
+		user *entities.User
+		task *entities.Task
+	)
+
+	g, groupCtx := errgroup.WithContext(ctx)
+
+	// находим пользователя по связи с партнёром и задание по коду параллельно
+	g.Go(func() error {
+		userID, err := s.storage.GetPartnerUserID(groupCtx, completion.Partner, completion.ExternalUserID)
+		if err != nil {
+			return err
+		}
+		user, err = s.storage.GetUserByID(groupCtx, userID)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		task, err = s.storage.GetTaskByCode(groupCtx, completion.TaskCode)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	metadata := map[string]string{
+		"source":           "webhook",
+		"partner":          completion.Partner,
+		"external_user_id": completion.ExternalUserID,
+	}
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.storage.SaveWebhookNonce(ctx, completion.Partner, completion.Nonce, time.Now().Add(-webhookNonceRetention)); err != nil {
+			return err
+		}
+
+		userTaskID, err := s.completeTask(ctx, user, task, metadata)
+		if err != nil {
+			return err
+		}
+
+		// тело доставки может занимать до мегабайта, поэтому хранится не в метаданных
+		return s.storage.SaveUserTaskPayload(ctx, userTaskID, completion.Payload)
+	})
+}
+
+// LinkPartnerUser связывает пользователя партнёра с нашим пользователем
+func (s *Service) LinkPartnerUser(ctx context.Context, link entities.PartnerUserLink) (*entities.PartnerUserLink, error) {
+	exists, err := s.storage.IsUserExists(ctx, link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return s.storage.UpsertPartnerUserLink(ctx, link)
+}
+
+// IsKnownPartner сообщает, настроен ли секрет для партнёра
+func (s *Service) IsKnownPartner(partner string) bool {
+	_, ok := s.webhookSecrets[partner]
+	return ok
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+// signWebhook подписывает тело так же, как партнёр
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	s, _ := newTestService(WithWebhookSecrets(map[string]string{"acme": "top-secret"}))
+
+	body := []byte(`{"task_code":"install"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-webhookTolerance-time.Minute).Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(webhookTolerance+time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		partner   string
+		timestamp string
+		signature string
+		body      []byte
+		want      error
+	}{
+		{name: "valid", partner: "acme", timestamp: now, signature: signWebhook("top-secret", now, body), body: body},
+		{name: "sha256 prefix", partner: "acme", timestamp: now, signature: "sha256=" + signWebhook("top-secret", now, body), body: body},
+		{name: "unknown partner", partner: "other", timestamp: now, signature: signWebhook("top-secret", now, body), body: body, want: entities.ErrUnknownPartner},
+		{name: "wrong secret", partner: "acme", timestamp: now, signature: signWebhook("guess", now, body), body: body, want: entities.ErrInvalidWebhookSignature},
+		{name: "tampered body", partner: "acme", timestamp: now, signature: signWebhook("top-secret", now, body), body: []byte(`{"task_code":"other"}`), want: entities.ErrInvalidWebhookSignature},
+		{name: "signature for another timestamp", partner: "acme", timestamp: now, signature: signWebhook("top-secret", stale, body), body: body, want: entities.ErrInvalidWebhookSignature},
+		{name: "stale timestamp", partner: "acme", timestamp: stale, signature: signWebhook("top-secret", stale, body), body: body, want: entities.ErrInvalidWebhookSignature},
+		{name: "future timestamp", partner: "acme", timestamp: future, signature: signWebhook("top-secret", future, body), body: body, want: entities.ErrInvalidWebhookSignature},
+		{name: "malformed timestamp", partner: "acme", timestamp: "yesterday", signature: signWebhook("top-secret", "yesterday", body), body: body, want: entities.ErrInvalidWebhookSignature},
+		{name: "malformed signature", partner: "acme", timestamp: now, signature: "not-hex", body: body, want: entities.ErrInvalidWebhookSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.VerifyWebhookSignature(tt.partner, tt.timestamp, tt.signature, tt.body); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyWebhookSignature() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletePartnerTaskRejectsReplayedNonce(t *testing.T) {
+	ctx := context.Background()
+	s, st := newTestService()
+	userID := st.addUser(nil)
+	taskID := st.addTask(15, 1, nil)
+	st.linkPartnerUser("acme", "ext-1", userID)
+
+	completion := entities.PartnerTaskCompletion{
+		Partner:        "acme",
+		ExternalUserID: "ext-1",
+		TaskCode:       taskID.String(),
+		Nonce:          "n-1",
+		Payload:        []byte(`{"nonce":"n-1"}`),
+	}
+	if err := s.CompletePartnerTask(ctx, completion); err != nil {
+		t.Fatalf("CompletePartnerTask() error = %v", err)
+	}
+
+	if err := s.CompletePartnerTask(ctx, completion); !errors.Is(err, entities.ErrWebhookReplay) {
+		t.Fatalf("CompletePartnerTask() replay error = %v, want %v", err, entities.ErrWebhookReplay)
+	}
+
+	if got := st.balance(t, userID); got != 15 {
+		t.Fatalf("balance = %d, want 15", got)
+	}
+	if got := len(st.payloads); got != 1 {
+		t.Fatalf("saved payloads = %d, want 1", got)
+	}
+}
+
+func TestCompletePartnerTaskReleasesNonceOnFailure(t *testing.T) {
+	ctx := context.Background()
+	completionCap := 1
+	s, st := newTestService()
+	taskID := st.addTask(15, 1, &completionCap)
+	first, second := st.addUser(nil), st.addUser(nil)
+	st.linkPartnerUser("acme", "ext-1", first)
+	st.linkPartnerUser("acme", "ext-2", second)
+
+	if err := s.CompletePartnerTask(ctx, entities.PartnerTaskCompletion{
+		Partner: "acme", ExternalUserID: "ext-1", TaskCode: taskID.String(), Nonce: "n-1",
+	}); err != nil {
+		t.Fatalf("CompletePartnerTask() error = %v", err)
+	}
+
+	// отказ откатывает транзакцию вместе с nonce, партнёр может повторить доставку
+	failed := entities.PartnerTaskCompletion{Partner: "acme", ExternalUserID: "ext-2", TaskCode: taskID.String(), Nonce: "n-2"}
+	for range 2 {
+		if err := s.CompletePartnerTask(ctx, failed); !errors.Is(err, entities.ErrTaskCapReached) {
+			t.Fatalf("CompletePartnerTask() error = %v, want %v", err, entities.ErrTaskCapReached)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAdjustmentBatch резервирует ключ идемпотентности.
+// Возвращает false, если ключ уже занят: при конкурентной вставке запрос дожидается
+// завершения чужой транзакции, поэтому после false сохранённый результат уже виден.
+func (s *Storage) CreateAdjustmentBatch(ctx context.Context, key, requestHash string) (bool, error) {
+	const query = `
+		INSERT INTO points_adjustment_batches (idempotency_key, request_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (idempotency_key) DO NOTHING
+	`
+
+	tag, err := s.conn(ctx).Exec(ctx, query, key, requestHash, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetAdjustmentBatch возвращает сохранённый набор корректировок по ключу идемпотентности
+func (s *Storage) GetAdjustmentBatch(ctx context.Context, key string) (*entities.PointsAdjustmentBatch, error) {
+	const batchQuery = `
+		SELECT idempotency_key, request_hash, created_at
+		FROM points_adjustment_batches
+		WHERE idempotency_key = $1
+	`
+
+	var batch entities.PointsAdjustmentBatch
+	err := s.conn(ctx).QueryRow(ctx, batchQuery, key).Scan(
+		&batch.IdempotencyKey,
+		&batch.RequestHash,
+		&batch.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	const adjustmentsQuery = `
+		SELECT id, user_id, admin_id, amount, reason_code, note, created_at
+		FROM points_adjustments
+		WHERE batch_key = $1
+		ORDER BY position
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, adjustmentsQuery, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batch.Adjustments = []entities.PointsAdjustment{}
+	for rows.Next() {
+		var (
+			a      entities.PointsAdjustment
+			reason string
+		)
+		if err := rows.Scan(
+			&a.ID,
+			&a.UserID,
+			&a.AdminID,
+			&a.Amount,
+			&reason,
+			&a.Note,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		a.ReasonCode = entities.AdjustmentReason(reason)
+		batch.Adjustments = append(batch.Adjustments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(batch.Adjustments) > 0 {
+		batch.AdminID = batch.Adjustments[0].AdminID
+	}
+
+	return &batch, nil
+}
+
+// CreateAdjustment сохраняет корректировку в журнал аудита.
+// position — порядковый номер корректировки в наборе.
+func (s *Storage) CreateAdjustment(ctx context.Context, key string, position int, adjustment entities.PointsAdjustment) (*entities.PointsAdjustment, error) {
+	const query = `
+		INSERT INTO points_adjustments (id, batch_key, position, user_id, admin_id, amount, reason_code, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		adjustment.ID,
+		key,
+		position,
+		adjustment.UserID,
+		adjustment.AdminID,
+		adjustment.Amount,
+		string(adjustment.ReasonCode),
+		adjustment.Note,
+		time.Now(),
+	).Scan(&adjustment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// BadgeModel — структура для таблицы badges
+type BadgeModel struct {
+	ID          uuid.UUID
+	Code        string
+	Title       string
+	Description string
+	Event       string
+	Metric      string
+	Threshold   int
+	CreatedAt   time.Time
+}
+
+// GetPendingBadges возвращает достижения, которые проверяются при событии
+// и ещё не выданы пользователю
+func (s *Storage) GetPendingBadges(ctx context.Context, userID uuid.UUID, event entities.BadgeEvent) ([]*entities.Badge, error) {
+	const query = `
+		SELECT b.id, b.code, b.title, COALESCE(b.description, ''), b.event, b.metric, b.threshold, b.created_at
+		FROM badges b
+		WHERE b.event = $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM user_badges ub
+		      WHERE ub.user_id = $1 AND ub.badge_id = b.id
+		  )
+		ORDER BY b.created_at
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, string(event))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var badges []*entities.Badge
+	for rows.Next() {
+		var m BadgeModel
+		if err := rows.Scan(
+			&m.ID,
+			&m.Code,
+			&m.Title,
+			&m.Description,
+			&m.Event,
+			&m.Metric,
+			&m.Threshold,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		badges = append(badges, mapBadgeModelToEntity(&m))
+	}
+
+	return badges, rows.Err()
+}
+
+// GetUserBadgeMetric возвращает текущее значение показателя пользователя для правил достижений
+func (s *Storage) GetUserBadgeMetric(ctx context.Context, userID uuid.UUID, metric entities.BadgeMetric) (int, error) {
+	var query string
+	switch metric {
+	case entities.BadgeMetricCompletedTasks:
+		query = `SELECT COUNT(*) FROM user_tasks WHERE user_id = $1 AND revoked_at IS NULL`
+	case entities.BadgeMetricReferrals:
+		query = `SELECT COUNT(*) FROM users WHERE referrer_id = $1`
+	case entities.BadgeMetricPoints:
+		query = `SELECT points FROM users WHERE id = $1`
+	default:
+		return 0, fmt.Errorf("unknown badge metric %q", metric)
+	}
+
+	var value int
+	if err := s.conn(ctx).QueryRow(ctx, query, userID).Scan(&value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// IsWithinLeaderboardRank сообщает, занимает ли пользователь в рейтинге по балансу
+// место не хуже rank. Место с учётом общих мест не хуже rank, если баллов не меньше,
+// чем у пользователя на позиции rank; позиция находится по idx_users_points_id
+// чтением rank строк индекса. Если пользователей меньше rank, подходит любой
+// пользователь с баллами; пользователь без баллов в рейтинг не попадает.
+func (s *Storage) IsWithinLeaderboardRank(ctx context.Context, userID uuid.UUID, rank int) (bool, error) {
+	const query = `
+		SELECT u.points > 0 AND u.points >= COALESCE((
+		           SELECT points
+		           FROM users
+		           ORDER BY points DESC, id
+		           OFFSET $2 - 1
+		           LIMIT 1
+		       ), 0)
+		FROM users u
+		WHERE u.id = $1
+	`
+
+	var within bool
+	err := s.conn(ctx).QueryRow(ctx, query, userID, rank).Scan(&within)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, entities.ErrUserNotFound
+	}
+
+	return within, err
+}
+
+// AwardBadge выдаёт достижение пользователю. Повторная выдача ничего не меняет,
+// в этом случае возвращается false.
+func (s *Storage) AwardBadge(ctx context.Context, userID, badgeID uuid.UUID) (bool, error) {
+	const query = `
+		INSERT INTO user_badges (user_id, badge_id, awarded_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, badge_id) DO NOTHING
+	`
+
+	tag, err := s.conn(ctx).Exec(ctx, query, userID, badgeID, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetUserBadges возвращает выданные пользователю достижения в порядке получения
+func (s *Storage) GetUserBadges(ctx context.Context, userID uuid.UUID) ([]entities.UserBadge, error) {
+	const query = `
+		SELECT b.id, b.code, b.title, COALESCE(b.description, ''), b.event, b.metric, b.threshold, b.created_at,
+		       ub.awarded_at
+		FROM user_badges ub
+		JOIN badges b ON b.id = ub.badge_id
+		WHERE ub.user_id = $1
+		ORDER BY ub.awarded_at, b.code
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	badges := []entities.UserBadge{}
+	for rows.Next() {
+		var (
+			m         BadgeModel
+			awardedAt time.Time
+		)
+		if err := rows.Scan(
+			&m.ID,
+			&m.Code,
+			&m.Title,
+			&m.Description,
+			&m.Event,
+			&m.Metric,
+			&m.Threshold,
+			&m.CreatedAt,
+			&awardedAt,
+		); err != nil {
+			return nil, err
+		}
+		badges = append(badges, entities.UserBadge{
+			Badge:     *mapBadgeModelToEntity(&m),
+			AwardedAt: awardedAt,
+		})
+	}
+
+	return badges, rows.Err()
+}
+
+// mapBadgeModelToEntity конвертирует модель базы в сущность
+func mapBadgeModelToEntity(m *BadgeModel) *entities.Badge {
+	return &entities.Badge{
+		ID:          m.ID,
+		Code:        m.Code,
+		Title:       m.Title,
+		Description: m.Description,
+		Event:       entities.BadgeEvent(m.Event),
+		Metric:      entities.BadgeMetric(m.Metric),
+		Threshold:   m.Threshold,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// taskTranslationQuery выбирает перевод задачи t по первой подходящей локали из $2.
+// Используется как LEFT JOIN LATERAL в запросах, где $2 — список локалей.
+const taskTranslationQuery = `
+	SELECT tt.title, tt.description
+	FROM task_translations tt
+	WHERE tt.task_id = t.id AND tt.locale = ANY($2::text[])
+	ORDER BY array_position($2::text[], tt.locale::text)
+	LIMIT 1
+`
+
+// TaskTranslationModel — структура для таблицы task_translations
+type TaskTranslationModel struct {
+	TaskID      uuid.UUID
+	Locale      string
+	Title       string
+	Description *string
+	UpdatedAt   time.Time
+}
+
+// ListTasks возвращает каталог задач с локализованными текстами
+func (s *Storage) ListTasks(ctx context.Context, filter entities.TaskFilter, locales []string) ([]*entities.Task, error) {
+	const query = `
+		SELECT t.id, t.code,
+		       COALESCE(tr.title, t.code),
+		       COALESCE(tr.description, t.description, ''),
+		       t.reward_points, t.target_count, t.progress_event,
+		       t.completion_cap, t.completions_count,
+		       COALESCE(c.code, ''),
+		       ARRAY(
+		           SELECT tg.code
+		           FROM task_tag_links l
+		           JOIN task_tags tg ON tg.id = l.tag_id
+		           WHERE l.task_id = t.id
+		           ORDER BY tg.code
+		       ),
+		       t.created_at
+		FROM tasks t
+		LEFT JOIN task_categories c ON c.id = t.category_id
+		LEFT JOIN LATERAL (` + taskTranslationQuery + `) tr ON TRUE
+		WHERE ($1::text = '' OR c.code = $1::text)
+		  AND ($3::text = '' OR EXISTS (
+		      SELECT 1
+		      FROM task_tag_links l
+		      JOIN task_tags tg ON tg.id = l.tag_id
+		      WHERE l.task_id = t.id AND tg.code = $3::text
+		  ))
+		ORDER BY t.created_at, t.code
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, filter.Category, locales, filter.Tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []*entities.Task{}
+	for rows.Next() {
+		var (
+			m        TaskModel
+			title    string
+			category string
+			tags     []string
+		)
+		if err := rows.Scan(
+			&m.ID,
+			&m.Code,
+			&title,
+			&m.Description,
+			&m.RewardPoints,
+			&m.TargetCount,
+			&m.ProgressEvent,
+			&m.CompletionCap,
+			&m.Completions,
+			&category,
+			&tags,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		t := mapTaskModelToEntity(&m)
+		t.Title = title
+		t.Category = category
+		t.Tags = tags
+		tasks = append(tasks, t)
+	}
+
+	return tasks, rows.Err()
+}
+
+// GetTaskTranslations возвращает все переводы задачи
+func (s *Storage) GetTaskTranslations(ctx context.Context, taskID uuid.UUID) ([]entities.TaskTranslation, error) {
+	const query = `
+		SELECT task_id, locale, title, description, updated_at
+		FROM task_translations
+		WHERE task_id = $1
+		ORDER BY locale
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []entities.TaskTranslation{}
+	for rows.Next() {
+		var m TaskTranslationModel
+		if err := rows.Scan(&m.TaskID, &m.Locale, &m.Title, &m.Description, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		translations = append(translations, mapTaskTranslationModelToEntity(&m))
+	}
+
+	return translations, rows.Err()
+}
+
+// UpsertTaskTranslation создаёт или обновляет перевод задачи на локаль
+func (s *Storage) UpsertTaskTranslation(ctx context.Context, tr entities.TaskTranslation) (*entities.TaskTranslation, error) {
+	const query = `
+		INSERT INTO task_translations (task_id, locale, title, description, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (task_id, locale) DO UPDATE
+		SET title       = EXCLUDED.title,
+		    description = EXCLUDED.description,
+		    updated_at  = EXCLUDED.updated_at
+		RETURNING task_id, locale, title, description, updated_at
+	`
+
+	var m TaskTranslationModel
+	err := s.conn(ctx).QueryRow(ctx, query, tr.TaskID, tr.Locale, tr.Title, tr.Description, time.Now()).Scan(
+		&m.TaskID,
+		&m.Locale,
+		&m.Title,
+		&m.Description,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := mapTaskTranslationModelToEntity(&m)
+	return &translation, nil
+}
+
+// DeleteTaskTranslation удаляет перевод задачи на локаль
+func (s *Storage) DeleteTaskTranslation(ctx context.Context, taskID uuid.UUID, locale string) error {
+	const query = `DELETE FROM task_translations WHERE task_id = $1 AND locale = $2`
+
+	tag, err := s.conn(ctx).Exec(ctx, query, taskID, locale)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entities.ErrTaskTranslationNotFound
+	}
+
+	return nil
+}
+
+// mapTaskTranslationModelToEntity конвертирует модель базы в сущность
+func mapTaskTranslationModelToEntity(m *TaskTranslationModel) entities.TaskTranslation {
+	tr := entities.TaskTranslation{
+		TaskID:    m.TaskID,
+		Locale:    m.Locale,
+		Title:     m.Title,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.Description != nil {
+		tr.Description = *m.Description
+	}
+	return tr
+}
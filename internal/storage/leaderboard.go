@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// earnedSinceQuery — заработок пользователей начиная с $1; в рейтинг за период
+// попадают только пользователи с положительным заработком
+const earnedSinceQuery = `
+	SELECT user_id, SUM(points) AS points
+	FROM points_earned_hourly
+	WHERE bucket_start >= $1
+	GROUP BY user_id
+	HAVING SUM(points) > 0
+`
+
+// AddEarnedPoints добавляет заработанные баллы в часовую корзину, в которую попадает момент at
+func (s *Storage) AddEarnedPoints(ctx context.Context, userID uuid.UUID, at time.Time, amount int) error {
+	const query = `
+		INSERT INTO points_earned_hourly (user_id, bucket_start, points)
+		VALUES ($1, date_trunc('hour', $2::timestamp), $3)
+		ON CONFLICT (user_id, bucket_start) DO UPDATE
+		SET points = points_earned_hourly.points + EXCLUDED.points
+	`
+
+	_, err := s.conn(ctx).Exec(ctx, query, userID, at, amount)
+	return err
+}
+
+// GetUsersLeaderboard возвращает страницу рейтинга по балансу.
+// При равных баллах пользователи делят место, порядок внутри места — по id.
+func (s *Storage) GetUsersLeaderboard(ctx context.Context, limit, offset int) (entities.UsersLeaderboard, error) {
+	const query = `
+		SELECT id, username, points, RANK() OVER (ORDER BY points DESC)
+		FROM users
+		ORDER BY points DESC, id
+		LIMIT $1 OFFSET $2
+	`
+
+	return s.queryLeaderboard(ctx, query, limit, offset)
+}
+
+// CountUsersLeaderboard возвращает число участников рейтинга по балансу
+func (s *Storage) CountUsersLeaderboard(ctx context.Context) (int, error) {
+	var total int
+	err := s.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&total)
+	return total, err
+}
+
+// GetUserLeaderboardPosition возвращает место пользователя в рейтинге по балансу.
+// Возвращает nil, если пользователь не найден.
+func (s *Storage) GetUserLeaderboardPosition(ctx context.Context, userID uuid.UUID) (*entities.UserLeader, error) {
+	const query = `
+		SELECT u.id, u.username, u.points,
+		       (SELECT COUNT(*) FROM users h WHERE h.points > u.points) + 1
+		FROM users u
+		WHERE u.id = $1
+	`
+
+	return s.queryLeaderboardPosition(ctx, query, userID)
+}
+
+// CountTiedAhead возвращает число пользователей с теми же баллами, что и у userID,
+// которые стоят в рейтинге по балансу выше него
+func (s *Storage) CountTiedAhead(ctx context.Context, userID uuid.UUID, points int) (int, error) {
+	const query = `SELECT COUNT(*) FROM users WHERE points = $1 AND id < $2`
+
+	var count int
+	err := s.conn(ctx).QueryRow(ctx, query, points, userID).Scan(&count)
+	return count, err
+}
+
+// GetLeaderboardRank возвращает место в рейтинге по балансу для заданного числа баллов
+func (s *Storage) GetLeaderboardRank(ctx context.Context, points int) (int, error) {
+	const query = `SELECT COUNT(*) + 1 FROM users WHERE points > $1`
+
+	var rank int
+	err := s.conn(ctx).QueryRow(ctx, query, points).Scan(&rank)
+	return rank, err
+}
+
+// GetLeaderboardAbove возвращает до limit пользователей, стоящих в рейтинге по балансу
+// непосредственно выше target, ближайший — первым. Места не заполняются.
+// Каждая ветка читает idx_users_points_id по ключу (points, id) без OFFSET.
+func (s *Storage) GetLeaderboardAbove(ctx context.Context, target entities.UserLeader, limit int) (entities.UsersLeaderboard, error) {
+	const query = `
+		SELECT id, username, points, 0
+		FROM (
+			(SELECT id, username, points
+			 FROM users
+			 WHERE points = $1 AND id < $2
+			 ORDER BY id DESC
+			 LIMIT $3)
+			UNION ALL
+			(SELECT id, username, points
+			 FROM users
+			 WHERE points > $1
+			 ORDER BY points, id DESC
+			 LIMIT $3)
+		) n
+		ORDER BY points, id DESC
+		LIMIT $3
+	`
+
+	return s.queryLeaderboard(ctx, query, target.Points, target.ID, limit)
+}
+
+// GetLeaderboardBelow возвращает до limit пользователей, стоящих в рейтинге по балансу
+// непосредственно ниже target, ближайший — первым. Места не заполняются.
+func (s *Storage) GetLeaderboardBelow(ctx context.Context, target entities.UserLeader, limit int) (entities.UsersLeaderboard, error) {
+	const query = `
+		SELECT id, username, points, 0
+		FROM (
+			(SELECT id, username, points
+			 FROM users
+			 WHERE points = $1 AND id > $2
+			 ORDER BY id
+			 LIMIT $3)
+			UNION ALL
+			(SELECT id, username, points
+			 FROM users
+			 WHERE points < $1
+			 ORDER BY points DESC, id
+			 LIMIT $3)
+		) n
+		ORDER BY points DESC, id
+		LIMIT $3
+	`
+
+	return s.queryLeaderboard(ctx, query, target.Points, target.ID, limit)
+}
+
+// GetPeriodLeaderboardPage возвращает страницу рейтинга по баллам, заработанным начиная с since,
+// число участников и место пользователя userID. Заработок агрегируется один раз:
+// страница, итог и строка пользователя выбираются из одного CTE.
+// Места считаются так же, как в рейтинге по балансу.
+func (s *Storage) GetPeriodLeaderboardPage(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) (*entities.LeaderboardPage, error) {
+	const query = `
+		WITH ranked AS (
+			SELECT e.user_id, e.points,
+			       RANK() OVER (ORDER BY e.points DESC) AS rank,
+			       ROW_NUMBER() OVER (ORDER BY e.points DESC, e.user_id) AS position
+			FROM (` + earnedSinceQuery + `) e
+		)
+		SELECT t.total, r.user_id, u.username, r.points, r.rank, r.position
+		FROM (SELECT COUNT(*) AS total FROM ranked) t
+		LEFT JOIN (ranked r JOIN users u ON u.id = r.user_id)
+		       ON (r.position > $4 AND r.position <= $4 + $3) OR r.user_id = $2
+		ORDER BY r.position
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, since, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &entities.LeaderboardPage{Users: entities.UsersLeaderboard{}}
+	for rows.Next() {
+		var (
+			id       *uuid.UUID
+			username *string
+			points   *int
+			rank     *int
+			position *int
+		)
+		if err := rows.Scan(&page.Total, &id, &username, &points, &rank, &position); err != nil {
+			return nil, err
+		}
+		// без участников на странице и без пользователя остаётся только итог
+		if id == nil {
+			continue
+		}
+
+		l := entities.UserLeader{ID: *id, Username: *username, Points: *points, Rank: *rank}
+		if *position > offset && *position <= offset+limit {
+			page.Users = append(page.Users, l)
+		}
+		if l.ID == userID {
+			page.Me = &l
+		}
+	}
+
+	return page, rows.Err()
+}
+
+func (s *Storage) queryLeaderboard(ctx context.Context, query string, args ...any) (entities.UsersLeaderboard, error) {
+	rows, err := s.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return entities.UsersLeaderboard{}, err
+	}
+	defer rows.Close()
+
+	leaders := entities.UsersLeaderboard{}
+	for rows.Next() {
+		var l entities.UserLeader
+		if err := rows.Scan(&l.ID, &l.Username, &l.Points, &l.Rank); err != nil {
+			return entities.UsersLeaderboard{}, err
+		}
+		leaders = append(leaders, l)
+	}
+
+	return leaders, rows.Err()
+}
+
+func (s *Storage) queryLeaderboardPosition(ctx context.Context, query string, args ...any) (*entities.UserLeader, error) {
+	var l entities.UserLeader
+	err := s.conn(ctx).QueryRow(ctx, query, args...).Scan(&l.ID, &l.Username, &l.Points, &l.Rank)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AddLedgerEntry записывает изменение баланса в журнал и обновляет users.points
+// одним запросом. Блокировка строки пользователя упорядочивает конкурентные записи,
+// поэтому balance_after всегда соответствует порядку журнала.
+func (s *Storage) AddLedgerEntry(ctx context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	return s.addLedgerEntry(ctx, entry, false)
+}
+
+// AddLedgerDebit записывает списание, которое не может увести баланс в минус.
+// При нехватке баллов возвращает ErrInsufficientPoints.
+func (s *Storage) AddLedgerDebit(ctx context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	return s.addLedgerEntry(ctx, entry, true)
+}
+
+func (s *Storage) addLedgerEntry(ctx context.Context, entry entities.LedgerEntry, requireFunds bool) (*entities.LedgerEntry, error) {
+	const query = `
+		WITH u AS (
+			UPDATE users
+			SET points = points + $2
+			WHERE id = $1 AND (NOT $6::bool OR points + $2 >= 0)
+			RETURNING points
+		)
+		INSERT INTO points_ledger (user_id, amount, reason_type, reference_id, balance_after, created_at)
+		SELECT $1, $2, $3, $4, u.points, $5
+		FROM u
+		RETURNING id, balance_after, created_at
+	`
+
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		entry.UserID,
+		entry.Amount,
+		string(entry.Reason),
+		entry.ReferenceID,
+		createdAt,
+		requireFunds,
+	).Scan(&entry.ID, &entry.BalanceAfter, &entry.CreatedAt)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if !requireFunds {
+			return nil, entities.ErrUserNotFound
+		}
+
+		exists, err := s.IsUserExists(ctx, entry.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entities.ErrUserNotFound
+		}
+		return nil, entities.ErrInsufficientPoints
+	}
+
+	return &entry, nil
+}
+
+// GetLedgerPage возвращает страницу журнала пользователя, новые записи первыми.
+// Пагинация по ключу (created_at, id), поэтому новые записи не сдвигают страницы.
+func (s *Storage) GetLedgerPage(ctx context.Context, userID uuid.UUID, filter entities.LedgerFilter) (*entities.LedgerPage, error) {
+	const query = `
+		SELECT l.id, l.user_id, l.amount, l.reason_type, l.reference_id, l.balance_after,
+		       COALESCE(t.code, ''), l.created_at
+		FROM points_ledger l
+		LEFT JOIN user_tasks ut ON ut.id = l.reference_id AND l.reason_type IN ('task_completed', 'task_revoked')
+		LEFT JOIN tasks t ON t.id = ut.task_id
+		WHERE l.user_id = $1
+		  AND (cardinality($2::text[]) = 0 OR l.reason_type = ANY($2::text[]))
+		  AND ($3::timestamp IS NULL OR l.created_at >= $3::timestamp)
+		  AND ($4::timestamp IS NULL OR l.created_at < $4::timestamp)
+		  AND ($5::timestamp IS NULL OR (l.created_at, l.id) < ($5::timestamp, $6::uuid))
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT $7
+	`
+
+	reasons := make([]string, len(filter.Reasons))
+	for i, r := range filter.Reasons {
+		reasons[i] = string(r)
+	}
+
+	var (
+		afterAt *time.Time
+		afterID *uuid.UUID
+	)
+	if filter.After != nil {
+		afterAt, afterID = &filter.After.CreatedAt, &filter.After.ID
+	}
+
+	// читаем на одну запись больше, чтобы понять, есть ли следующая страница
+	rows, err := s.conn(ctx).Query(ctx, query,
+		userID,
+		reasons,
+		filter.From,
+		filter.To,
+		afterAt,
+		afterID,
+		filter.Limit+1,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &entities.LedgerPage{Entries: []entities.LedgerEntry{}}
+	for rows.Next() {
+		var (
+			e      entities.LedgerEntry
+			reason string
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.UserID,
+			&e.Amount,
+			&reason,
+			&e.ReferenceID,
+			&e.BalanceAfter,
+			&e.TaskCode,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Reason = entities.LedgerReason(reason)
+		page.Entries = append(page.Entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Entries) > filter.Limit {
+		page.Entries = page.Entries[:filter.Limit]
+		last := page.Entries[len(page.Entries)-1]
+		page.Next = &entities.LedgerPosition{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MultiplierEventModel — структура для таблицы points_multiplier_events
+type MultiplierEventModel struct {
+	ID         uuid.UUID
+	Name       string
+	Multiplier float64
+	StartsAt   time.Time
+	EndsAt     time.Time
+	TaskIDs    []uuid.UUID
+	Categories []string
+	CreatedAt  time.Time
+}
+
+// CreateMultiplierEvent создаёт событие-множитель вместе с его областью действия.
+// Все коды категорий должны существовать, иначе возвращается ErrTaskCategoryNotFound.
+func (s *Storage) CreateMultiplierEvent(ctx context.Context, event entities.MultiplierEvent) (*entities.MultiplierEvent, error) {
+	const insertQuery = `
+		INSERT INTO points_multiplier_events (name, multiplier, starts_at, ends_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, insertQuery,
+		event.Name,
+		event.Multiplier,
+		event.StartsAt,
+		event.EndsAt,
+		time.Now(),
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(event.TaskIDs) > 0 {
+		const tasksQuery = `
+			INSERT INTO points_multiplier_event_tasks (event_id, task_id)
+			SELECT $1, t.id FROM tasks t WHERE t.id = ANY($2)
+		`
+		tag, err := s.conn(ctx).Exec(ctx, tasksQuery, event.ID, event.TaskIDs)
+		if err != nil {
+			return nil, err
+		}
+		if int(tag.RowsAffected()) != len(event.TaskIDs) {
+			return nil, entities.ErrTaskNotFound
+		}
+	}
+
+	if len(event.Categories) > 0 {
+		const categoriesQuery = `
+			INSERT INTO points_multiplier_event_categories (event_id, category_id)
+			SELECT $1, c.id FROM task_categories c WHERE c.code = ANY($2)
+		`
+		tag, err := s.conn(ctx).Exec(ctx, categoriesQuery, event.ID, event.Categories)
+		if err != nil {
+			return nil, err
+		}
+		if int(tag.RowsAffected()) != len(event.Categories) {
+			return nil, entities.ErrTaskCategoryNotFound
+		}
+	}
+
+	return &event, nil
+}
+
+// ListMultiplierEvents возвращает все события-множители, новые первыми
+func (s *Storage) ListMultiplierEvents(ctx context.Context) ([]*entities.MultiplierEvent, error) {
+	const query = `
+		SELECT e.id, e.name, e.multiplier, e.starts_at, e.ends_at,
+		       ARRAY(SELECT et.task_id FROM points_multiplier_event_tasks et WHERE et.event_id = e.id),
+		       ARRAY(
+		           SELECT c.code
+		           FROM points_multiplier_event_categories ec
+		           JOIN task_categories c ON c.id = ec.category_id
+		           WHERE ec.event_id = e.id
+		           ORDER BY c.code
+		       ),
+		       e.created_at
+		FROM points_multiplier_events e
+		ORDER BY e.starts_at DESC
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entities.MultiplierEvent{}
+	for rows.Next() {
+		var m MultiplierEventModel
+		if err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Multiplier,
+			&m.StartsAt,
+			&m.EndsAt,
+			&m.TaskIDs,
+			&m.Categories,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, mapMultiplierEventModelToEntity(&m))
+	}
+
+	return events, rows.Err()
+}
+
+// GetActiveMultiplierEvent возвращает событие с наибольшим множителем, действующее
+// на задачу в момент at. Если таких нет — nil без ошибки.
+func (s *Storage) GetActiveMultiplierEvent(ctx context.Context, taskID uuid.UUID, at time.Time) (*entities.MultiplierEvent, error) {
+	const query = `
+		SELECT e.id, e.name, e.multiplier, e.starts_at, e.ends_at, e.created_at
+		FROM points_multiplier_events e
+		WHERE e.starts_at <= $2 AND e.ends_at > $2
+		  AND (
+		      (
+		          NOT EXISTS (SELECT 1 FROM points_multiplier_event_tasks et WHERE et.event_id = e.id)
+		          AND NOT EXISTS (SELECT 1 FROM points_multiplier_event_categories ec WHERE ec.event_id = e.id)
+		      )
+		      OR EXISTS (
+		          SELECT 1 FROM points_multiplier_event_tasks et
+		          WHERE et.event_id = e.id AND et.task_id = $1
+		      )
+		      OR EXISTS (
+		          SELECT 1 FROM points_multiplier_event_categories ec
+		          JOIN tasks t ON t.category_id = ec.category_id
+		          WHERE ec.event_id = e.id AND t.id = $1
+		      )
+		  )
+		ORDER BY e.multiplier DESC, e.starts_at
+		LIMIT 1
+	`
+
+	var m MultiplierEventModel
+	err := s.conn(ctx).QueryRow(ctx, query, taskID, at).Scan(
+		&m.ID,
+		&m.Name,
+		&m.Multiplier,
+		&m.StartsAt,
+		&m.EndsAt,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mapMultiplierEventModelToEntity(&m), nil
+}
+
+// mapMultiplierEventModelToEntity конвертирует модель базы в сущность
+func mapMultiplierEventModelToEntity(m *MultiplierEventModel) *entities.MultiplierEvent {
+	return &entities.MultiplierEvent{
+		ID:         m.ID,
+		Name:       m.Name,
+		Multiplier: m.Multiplier,
+		StartsAt:   m.StartsAt,
+		EndsAt:     m.EndsAt,
+		TaskIDs:    m.TaskIDs,
+		Categories: m.Categories,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// CreatePointsLot сохраняет партию начисленных баллов
+func (s *Storage) CreatePointsLot(ctx context.Context, lot entities.PointsLot) (*entities.PointsLot, error) {
+	const query = `
+		INSERT INTO points_lots (user_id, ledger_entry_id, source, amount, remaining, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		lot.UserID,
+		lot.LedgerEntryID,
+		string(lot.Source),
+		lot.Amount,
+		lot.Remaining,
+		lot.ExpiresAt,
+		time.Now(),
+	).Scan(&lot.ID, &lot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+// ConsumePointsLots расходует amount баллов из партий пользователя, начиная с самых старых.
+// Если остатков не хватает, расходуется всё, что есть: долг покрывается следующими начислениями.
+func (s *Storage) ConsumePointsLots(ctx context.Context, userID uuid.UUID, amount int) error {
+	const query = `
+		WITH locked AS (
+			SELECT id, remaining, created_at
+			FROM points_lots
+			WHERE user_id = $1 AND remaining > 0
+			FOR UPDATE
+		), ordered AS (
+			SELECT id, remaining, SUM(remaining) OVER (ORDER BY created_at, id) - remaining AS before
+			FROM locked
+		)
+		UPDATE points_lots l
+		SET remaining = l.remaining - LEAST(o.remaining, $2 - o.before)
+		FROM ordered o
+		WHERE l.id = o.id AND o.before < $2
+	`
+
+	_, err := s.conn(ctx).Exec(ctx, query, userID, amount)
+	return err
+}
+
+// GetUsersWithExpiredLots возвращает до limit пользователей, у которых есть
+// несписанные остатки партий со сроком до at
+func (s *Storage) GetUsersWithExpiredLots(ctx context.Context, at time.Time, limit int) ([]uuid.UUID, error) {
+	const query = `
+		SELECT DISTINCT user_id
+		FROM points_lots
+		WHERE remaining > 0 AND expires_at <= $1
+		LIMIT $2
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, at, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// ExpireUserLots обнуляет остатки партий пользователя со сроком до at.
+// Возвращает обнулённые партии, Remaining содержит сгоревший остаток.
+func (s *Storage) ExpireUserLots(ctx context.Context, userID uuid.UUID, at time.Time) ([]entities.PointsLot, error) {
+	const query = `
+		WITH expired AS (
+			SELECT id, remaining
+			FROM points_lots
+			WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+			FOR UPDATE
+		)
+		UPDATE points_lots l
+		SET remaining = 0
+		FROM expired e
+		WHERE l.id = e.id
+		RETURNING l.id, l.user_id, l.ledger_entry_id, l.source, l.amount, e.remaining, l.expires_at, l.created_at
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []entities.PointsLot
+	for rows.Next() {
+		var (
+			lot    entities.PointsLot
+			source string
+		)
+		if err := rows.Scan(
+			&lot.ID,
+			&lot.UserID,
+			&lot.LedgerEntryID,
+			&source,
+			&lot.Amount,
+			&lot.Remaining,
+			&lot.ExpiresAt,
+			&lot.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		lot.Source = entities.LedgerReason(source)
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// GetExpiringPoints возвращает остатки партий пользователя, сгорающие до before
+func (s *Storage) GetExpiringPoints(ctx context.Context, userID uuid.UUID, before time.Time) ([]entities.ExpiringPoints, error) {
+	const query = `
+		SELECT remaining, expires_at
+		FROM points_lots
+		WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+		ORDER BY expires_at, created_at
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expiring := []entities.ExpiringPoints{}
+	for rows.Next() {
+		var e entities.ExpiringPoints
+		if err := rows.Scan(&e.Points, &e.ExpiresAt); err != nil {
+			return nil, err
+		}
+		expiring = append(expiring, e)
+	}
+
+	return expiring, rows.Err()
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// FindUnpostedTaskEntries возвращает проводки по выполнениям и отзывам, которых нет в журнале,
+// в хронологическом порядке, со временем выполнения или отзыва. Выполнения и отзывы до появления
+// журнала представлены во входящем балансе и отдельными проводками не дописываются; расхождение
+// входящего баланса с ними находит сравнение с ожидаемым балансом (GetDriftedBalances).
+// userID ограничивает поиск одним пользователем, nil — все пользователи.
+func (s *Storage) FindUnpostedTaskEntries(ctx context.Context, userID *uuid.UUID) ([]entities.LedgerEntry, error) {
+	const query = `
+		WITH cutoff AS (
+			SELECT COALESCE(MIN(created_at), '-infinity'::timestamp) AS at
+			FROM points_ledger
+			WHERE reason_type = 'opening_balance'
+		)
+		SELECT e.user_id, e.amount, e.reason_type, e.reference_id, e.at
+		FROM (
+			SELECT ut.user_id, ut.points AS amount, 'task_completed' AS reason_type, ut.id AS reference_id, ut.completed_at AS at
+			FROM user_tasks ut, cutoff c
+			WHERE ut.points <> 0 AND ut.completed_at >= c.at
+			  AND ($1::uuid IS NULL OR ut.user_id = $1::uuid)
+			  AND NOT EXISTS (
+			      SELECT 1 FROM points_ledger l
+			      WHERE l.reference_id = ut.id AND l.reason_type = 'task_completed'
+			  )
+			UNION ALL
+			SELECT ut.user_id, -ut.points, 'task_revoked', ut.id, ut.revoked_at
+			FROM user_tasks ut, cutoff c
+			WHERE ut.points <> 0 AND ut.revoked_at IS NOT NULL AND ut.revoked_at >= c.at
+			  AND ($1::uuid IS NULL OR ut.user_id = $1::uuid)
+			  AND NOT EXISTS (
+			      SELECT 1 FROM points_ledger l
+			      WHERE l.reference_id = ut.id AND l.reason_type = 'task_revoked'
+			  )
+		) e
+		ORDER BY e.at, e.reference_id
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entities.LedgerEntry
+	for rows.Next() {
+		var (
+			e      entities.LedgerEntry
+			reason string
+		)
+		if err := rows.Scan(&e.UserID, &e.Amount, &reason, &e.ReferenceID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Reason = entities.LedgerReason(reason)
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// expectedBalancesCTE — ожидаемые балансы пользователей по источнику истины: награды
+// за неотозванные выполнения из user_tasks, включая выполненные до появления журнала,
+// и остальные проводки журнала. Входящий баланс и проводки сверки в ожидаемый баланс
+// не входят: первый заменяется выполнениями до журнала, вторые лишь поправляют журнал до него.
+const expectedBalancesCTE = `
+	balances AS (
+		SELECT u.id AS user_id,
+		       u.points,
+		       COALESCE((
+		           SELECT SUM(ut.points)
+		           FROM user_tasks ut
+		           WHERE ut.user_id = u.id AND ut.revoked_at IS NULL
+		       ), 0) + COALESCE((
+		           SELECT SUM(l.amount)
+		           FROM points_ledger l
+		           WHERE l.user_id = u.id
+		             AND l.reason_type NOT IN ('task_completed', 'task_revoked', 'opening_balance', 'reconciliation')
+		       ), 0) AS expected,
+		       COALESCE((SELECT SUM(l.amount) FROM points_ledger l WHERE l.user_id = u.id), 0) AS ledger_total
+		FROM users u
+	)`
+
+// GetDriftedBalances возвращает балансы пользователей, у которых users.points или сумма
+// журнала не совпадают с ожидаемым балансом
+func (s *Storage) GetDriftedBalances(ctx context.Context) ([]entities.UserBalance, error) {
+	const query = `WITH ` + expectedBalancesCTE + `
+		SELECT user_id, points, ledger_total, expected
+		FROM balances
+		WHERE points <> expected OR ledger_total <> expected
+		ORDER BY user_id
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []entities.UserBalance
+	for rows.Next() {
+		var b entities.UserBalance
+		if err := rows.Scan(&b.UserID, &b.Points, &b.LedgerTotal, &b.Expected); err != nil {
+			return nil, err
+		}
+		balances = append(balances, b)
+	}
+
+	return balances, rows.Err()
+}
+
+// AddBackfilledLedgerEntry записывает пропущенную проводку задним числом, не меняя users.points:
+// баланс выравнивается одной проводкой сверки после всех пропущенных. balance_after
+// считается по журналу пользователя. Строка пользователя должна быть заблокирована.
+func (s *Storage) AddBackfilledLedgerEntry(ctx context.Context, entry entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	const query = `
+		INSERT INTO points_ledger (user_id, amount, reason_type, reference_id, balance_after, created_at)
+		SELECT $1, $2, $3, $4,
+		       COALESCE((SELECT SUM(amount) FROM points_ledger WHERE user_id = $1), 0) + $2,
+		       $5
+		RETURNING id, balance_after, created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		entry.UserID,
+		entry.Amount,
+		string(entry.Reason),
+		entry.ReferenceID,
+		entry.CreatedAt,
+	).Scan(&entry.ID, &entry.BalanceAfter, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// AddReconciliationEntry выставляет users.points в ожидаемый баланс и записывает в журнал
+// проводку reconciliation на разницу между ожидаемым балансом и суммой журнала.
+// Возвращает nil, если журнал уже сходится; users.points при этом всё равно выравнивается.
+// Строка пользователя должна быть заблокирована.
+func (s *Storage) AddReconciliationEntry(ctx context.Context, userID uuid.UUID) (*entities.LedgerEntry, error) {
+	const query = `WITH ` + expectedBalancesCTE + `,
+		e AS (
+			SELECT user_id, expected, expected - ledger_total AS amount
+			FROM balances
+			WHERE user_id = $1
+		),
+		u AS (
+			UPDATE users
+			SET points = e.expected
+			FROM e
+			WHERE users.id = e.user_id AND users.points <> e.expected
+		)
+		INSERT INTO points_ledger (user_id, amount, reason_type, reference_id, balance_after, created_at)
+		SELECT e.user_id, e.amount, $2, NULL, e.expected, $3
+		FROM e
+		WHERE e.amount <> 0
+		RETURNING id, user_id, amount, balance_after, created_at
+	`
+
+	entry := entities.LedgerEntry{Reason: entities.LedgerReasonReconciliation}
+	err := s.conn(ctx).QueryRow(ctx, query, userID, string(entry.Reason), time.Now()).
+		Scan(&entry.ID, &entry.UserID, &entry.Amount, &entry.BalanceAfter, &entry.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
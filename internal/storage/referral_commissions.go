@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// GetReferrerChain возвращает рефереров пользователя вверх по цепочке, не глубже maxDepth.
+// Повторно встреченный пользователь обрывает цепочку, поэтому цикл в данных не зацикливает запрос.
+func (s *Storage) GetReferrerChain(ctx context.Context, userID uuid.UUID, maxDepth int) ([]entities.ReferralAncestor, error) {
+	const query = `
+		WITH RECURSIVE chain (user_id, level, path) AS (
+			SELECT u.referrer_id, 1, ARRAY[u.id, u.referrer_id]
+			FROM users u
+			WHERE u.id = $1 AND u.referrer_id IS NOT NULL AND u.referrer_id <> u.id
+			UNION ALL
+			SELECT u.referrer_id, c.level + 1, c.path || u.referrer_id
+			FROM chain c
+			JOIN users u ON u.id = c.user_id
+			WHERE u.referrer_id IS NOT NULL
+			  AND c.level < $2
+			  AND NOT u.referrer_id = ANY(c.path)
+		)
+		SELECT user_id, level
+		FROM chain
+		ORDER BY level
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chain []entities.ReferralAncestor
+	for rows.Next() {
+		var a entities.ReferralAncestor
+		if err := rows.Scan(&a.UserID, &a.Level); err != nil {
+			return nil, err
+		}
+		chain = append(chain, a)
+	}
+
+	return chain, rows.Err()
+}
+
+// CreateReferralCommission сохраняет комиссию реферера
+func (s *Storage) CreateReferralCommission(ctx context.Context, commission entities.ReferralCommission) (*entities.ReferralCommission, error) {
+	const query = `
+		INSERT INTO referral_commissions (
+			id, beneficiary_id, source_user_id, level, source_entry_id,
+			source_reason, source_amount, rate_bps, amount, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		commission.ID,
+		commission.BeneficiaryID,
+		commission.SourceUserID,
+		commission.Level,
+		commission.SourceEntryID,
+		string(commission.SourceReason),
+		commission.SourceAmount,
+		commission.RateBps,
+		commission.Amount,
+		time.Now(),
+	).Scan(&commission.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commission, nil
+}
+
+// GetUncommissionedTaskEntries возвращает начисления за выполнения пользователя с since,
+// с которых не выплачено ни одной комиссии: пока приглашение ждало проверки, комиссии
+// задерживались. Начисления отозванных выполнений не возвращаются.
+func (s *Storage) GetUncommissionedTaskEntries(ctx context.Context, userID uuid.UUID, since time.Time) ([]entities.LedgerEntry, error) {
+	const query = `
+		SELECT l.id, l.user_id, l.amount, l.reason_type, l.reference_id, l.balance_after, l.created_at
+		FROM points_ledger l
+		JOIN user_tasks ut ON ut.id = l.reference_id AND ut.revoked_at IS NULL
+		WHERE l.user_id = $1
+		  AND l.reason_type = 'task_completed'
+		  AND l.amount > 0
+		  AND l.created_at >= $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM referral_commissions c WHERE c.source_entry_id = l.id
+		  )
+		ORDER BY l.created_at, l.id
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entities.LedgerEntry
+	for rows.Next() {
+		var (
+			e      entities.LedgerEntry
+			reason string
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.UserID,
+			&e.Amount,
+			&reason,
+			&e.ReferenceID,
+			&e.BalanceAfter,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Reason = entities.LedgerReason(reason)
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetReferralTree возвращает сеть приглашённых пользователя не глубже maxDepth
+// вместе с комиссиями, которые пользователь получил с каждого участника
+func (s *Storage) GetReferralTree(ctx context.Context, userID uuid.UUID, maxDepth int) ([]entities.ReferralTreeNode, error) {
+	const query = `
+		WITH RECURSIVE tree (user_id, referrer_id, level, path) AS (
+			SELECT u.id, u.referrer_id, 1, ARRAY[$1::uuid, u.id]
+			FROM users u
+			WHERE u.referrer_id = $1
+			UNION ALL
+			SELECT u.id, u.referrer_id, t.level + 1, t.path || u.id
+			FROM tree t
+			JOIN users u ON u.referrer_id = t.user_id
+			WHERE t.level < $2
+			  AND NOT u.id = ANY(t.path)
+		)
+		SELECT t.user_id, u.username, t.referrer_id, t.level,
+		       COALESCE(c.earned, 0), u.created_at
+		FROM tree t
+		JOIN users u ON u.id = t.user_id
+		LEFT JOIN (
+			SELECT source_user_id, SUM(amount) AS earned
+			FROM referral_commissions
+			WHERE beneficiary_id = $1 AND reversed_at IS NULL
+			GROUP BY source_user_id
+		) c ON c.source_user_id = t.user_id
+		ORDER BY t.level, u.created_at, t.user_id
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, userID, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := []entities.ReferralTreeNode{}
+	for rows.Next() {
+		var n entities.ReferralTreeNode
+		if err := rows.Scan(
+			&n.UserID,
+			&n.Username,
+			&n.ReferrerID,
+			&n.Level,
+			&n.Earned,
+			&n.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+
+	return nodes, rows.Err()
+}
+
+// ReverseReferralCommissions помечает списанными действующие комиссии, выплаченные
+// с начисления reason по reference и возвращает их. Уже списанные комиссии не возвращаются,
+// поэтому повторный отзыв не списывает комиссию дважды.
+func (s *Storage) ReverseReferralCommissions(ctx context.Context, reason entities.LedgerReason, referenceID uuid.UUID) ([]entities.ReferralCommission, error) {
+	const query = `
+		UPDATE referral_commissions c
+		SET reversed_at = $3
+		FROM points_ledger l
+		WHERE l.id = c.source_entry_id
+		  AND l.reason_type = $1
+		  AND l.reference_id = $2
+		  AND c.reversed_at IS NULL
+		RETURNING c.id, c.beneficiary_id, c.source_user_id, c.level, c.source_entry_id,
+		          c.source_reason, c.source_amount, c.rate_bps, c.amount, c.created_at, c.reversed_at
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, string(reason), referenceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var commissions []entities.ReferralCommission
+	for rows.Next() {
+		var (
+			c            entities.ReferralCommission
+			sourceReason string
+		)
+		if err := rows.Scan(
+			&c.ID,
+			&c.BeneficiaryID,
+			&c.SourceUserID,
+			&c.Level,
+			&c.SourceEntryID,
+			&sourceReason,
+			&c.SourceAmount,
+			&c.RateBps,
+			&c.Amount,
+			&c.CreatedAt,
+			&c.ReversedAt,
+		); err != nil {
+			return nil, err
+		}
+		c.SourceReason = entities.LedgerReason(sourceReason)
+		commissions = append(commissions, c)
+	}
+
+	return commissions, rows.Err()
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// referralGraphLockKey — ключ advisory-блокировки изменений графа рефералов
+const referralGraphLockKey = "referral_graph"
+
+// LockReferralGraph блокирует изменения графа рефералов до конца транзакции.
+// Цикл могут замкнуть две параллельные установки реферера у разных пользователей,
+// поэтому блокировки строк самих пользователей недостаточно.
+func (s *Storage) LockReferralGraph(ctx context.Context) error {
+	const query = `SELECT pg_advisory_xact_lock(hashtext($1))`
+
+	_, err := s.conn(ctx).Exec(ctx, query, referralGraphLockKey)
+	return err
+}
+
+// IsReferralDescendant сообщает, приглашён ли candidateID пользователем userID
+// напрямую или через цепочку рефереров. Глубина цепочки не ограничена,
+// уже встреченный пользователь обрывает обход.
+func (s *Storage) IsReferralDescendant(ctx context.Context, userID, candidateID uuid.UUID) (bool, error) {
+	const query = `
+		WITH RECURSIVE chain (user_id, path) AS (
+			SELECT u.referrer_id, ARRAY[u.id]
+			FROM users u
+			WHERE u.id = $2 AND u.referrer_id IS NOT NULL
+			UNION ALL
+			SELECT u.referrer_id, c.path || u.id
+			FROM chain c
+			JOIN users u ON u.id = c.user_id
+			WHERE u.referrer_id IS NOT NULL
+			  AND NOT u.id = ANY(c.path)
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE user_id = $1)
+	`
+
+	var descendant bool
+	if err := s.conn(ctx).QueryRow(ctx, query, userID, candidateID).Scan(&descendant); err != nil {
+		return false, err
+	}
+
+	return descendant, nil
+}
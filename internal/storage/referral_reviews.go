@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ReferralReviewModel — структура для таблицы referral_reviews
+type ReferralReviewModel struct {
+	ID         uuid.UUID
+	RefereeID  uuid.UUID
+	ReferrerID uuid.UUID
+	Trigger    string
+	Score      int
+	Signals    []string
+	Status     string
+	ReviewedBy *uuid.UUID
+	Note       string
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
+}
+
+// referralReviewColumns — колонки, которые читает scanReferralReview
+const referralReviewColumns = `
+	id, referee_id, referrer_id, trigger, score, signals, status,
+	reviewed_by, COALESCE(note, ''), created_at, reviewed_at
+`
+
+// GetReferralFraudStats собирает данные для оценки приглашения refereeID реферером referrerID.
+// Сетью реферера считаются он сам и его прямые приглашённые. Всплеск считается с burstSince,
+// активность — по приглашённым не позже idleBefore, чтобы новые приглашённые не портили долю.
+func (s *Storage) GetReferralFraudStats(ctx context.Context, refereeID, referrerID uuid.UUID, burstSince, idleBefore time.Time) (*entities.ReferralFraudStats, error) {
+	const query = `
+		WITH referee AS (
+			SELECT signup_ip, signup_user_agent FROM users WHERE id = $1
+		),
+		network AS (
+			SELECT u.id, u.signup_ip, u.signup_user_agent, u.referred_at
+			FROM users u
+			WHERE (u.id = $2 OR u.referrer_id = $2) AND u.id <> $1
+		)
+		SELECT
+			(SELECT COUNT(*) FROM network n, referee r WHERE n.signup_ip = r.signup_ip),
+			(SELECT COUNT(*) FROM network n, referee r WHERE n.signup_user_agent = r.signup_user_agent),
+			(SELECT COUNT(*) FROM users u WHERE u.referrer_id = $2 AND u.referred_at >= $3),
+			(SELECT COUNT(*) FROM network n WHERE n.id <> $2 AND n.referred_at <= $4),
+			(
+				SELECT COUNT(*) FROM network n
+				WHERE n.id <> $2 AND n.referred_at <= $4
+				  AND NOT EXISTS (
+				      SELECT 1
+				      FROM user_tasks ut
+				      JOIN tasks t ON t.id = ut.task_id
+				      LEFT JOIN task_categories c ON c.id = t.category_id
+				      WHERE ut.user_id = n.id AND ut.revoked_at IS NULL
+				        AND c.code IS DISTINCT FROM 'referral'
+				  )
+			)
+	`
+
+	var stats entities.ReferralFraudStats
+	err := s.conn(ctx).QueryRow(ctx, query, refereeID, referrerID, burstSince, idleBefore).Scan(
+		&stats.SharedIP,
+		&stats.SharedUserAgent,
+		&stats.RecentReferrals,
+		&stats.Referees,
+		&stats.IdleReferees,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// CreateReferralReview ставит приглашение в очередь проверки
+func (s *Storage) CreateReferralReview(ctx context.Context, review entities.ReferralReview) (*entities.ReferralReview, error) {
+	const query = `
+		INSERT INTO referral_reviews (referee_id, referrer_id, trigger, score, signals, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, 'pending', $6)
+		RETURNING ` + referralReviewColumns
+
+	signals := make([]string, len(review.Signals))
+	for i, signal := range review.Signals {
+		signals[i] = string(signal)
+	}
+
+	return scanReferralReview(s.conn(ctx).QueryRow(ctx, query,
+		review.RefereeID,
+		review.ReferrerID,
+		string(review.Trigger),
+		review.Score,
+		signals,
+		time.Now(),
+	))
+}
+
+// GetLatestReferralReview возвращает последнюю проверку приглашения пользователя
+// или nil, если приглашение не проверялось
+func (s *Storage) GetLatestReferralReview(ctx context.Context, refereeID uuid.UUID) (*entities.ReferralReview, error) {
+	const query = `
+		SELECT ` + referralReviewColumns + `
+		FROM referral_reviews
+		WHERE referee_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	review, err := scanReferralReview(s.conn(ctx).QueryRow(ctx, query, refereeID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return review, err
+}
+
+// ListReferralReviews возвращает очередь проверки, старые приглашения первыми
+func (s *Storage) ListReferralReviews(ctx context.Context, filter entities.ReferralReviewFilter) ([]*entities.ReferralReview, error) {
+	const query = `
+		SELECT ` + referralReviewColumns + `
+		FROM referral_reviews
+		WHERE ($1::text = '' OR status = $1::text)
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, string(filter.Status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*entities.ReferralReview{}
+	for rows.Next() {
+		review, err := scanReferralReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// DecideReferralReview переводит ожидающую проверку в итоговый статус.
+// Решённая проверка не меняется и возвращает ErrReferralReviewNotPending.
+func (s *Storage) DecideReferralReview(ctx context.Context, decision entities.ReferralReviewDecision) (*entities.ReferralReview, error) {
+	const query = `
+		UPDATE referral_reviews
+		SET status      = $2,
+		    reviewed_by = $3,
+		    note        = NULLIF($4, ''),
+		    reviewed_at = $5
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + referralReviewColumns
+
+	review, err := scanReferralReview(s.conn(ctx).QueryRow(ctx, query,
+		decision.ReviewID,
+		string(decision.Status),
+		decision.AdminID,
+		decision.Note,
+		time.Now(),
+	))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+
+		const existsQuery = `SELECT 1 FROM referral_reviews WHERE id = $1`
+		var tmp int
+		if err := s.conn(ctx).QueryRow(ctx, existsQuery, decision.ReviewID).Scan(&tmp); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, entities.ErrReferralReviewNotFound
+			}
+			return nil, err
+		}
+		return nil, entities.ErrReferralReviewNotPending
+	}
+
+	return review, nil
+}
+
+// scanReferralReview читает проверку из строки с колонками referralReviewColumns
+func scanReferralReview(row pgx.Row) (*entities.ReferralReview, error) {
+	var m ReferralReviewModel
+	if err := row.Scan(
+		&m.ID,
+		&m.RefereeID,
+		&m.ReferrerID,
+		&m.Trigger,
+		&m.Score,
+		&m.Signals,
+		&m.Status,
+		&m.ReviewedBy,
+		&m.Note,
+		&m.CreatedAt,
+		&m.ReviewedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return mapReferralReviewModelToEntity(&m), nil
+}
+
+// mapReferralReviewModelToEntity конвертирует модель базы в сущность
+func mapReferralReviewModelToEntity(m *ReferralReviewModel) *entities.ReferralReview {
+	signals := make([]entities.FraudSignal, len(m.Signals))
+	for i, signal := range m.Signals {
+		signals[i] = entities.FraudSignal(signal)
+	}
+
+	return &entities.ReferralReview{
+		ID:         m.ID,
+		RefereeID:  m.RefereeID,
+		ReferrerID: m.ReferrerID,
+		Trigger:    entities.ReferralReviewTrigger(m.Trigger),
+		Score:      m.Score,
+		Signals:    signals,
+		Status:     entities.ReferralReviewStatus(m.Status),
+		ReviewedBy: m.ReviewedBy,
+		Note:       m.Note,
+		CreatedAt:  m.CreatedAt,
+		ReviewedAt: m.ReviewedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateReferralReward сохраняет бонусы за приглашение
+func (s *Storage) CreateReferralReward(ctx context.Context, reward entities.ReferralReward) (*entities.ReferralReward, error) {
+	const query = `
+		INSERT INTO referral_rewards (referee_id, referrer_id, referee_bonus, referrer_bonus, referrer_after_tasks, referrer_paid_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		reward.RefereeID,
+		reward.ReferrerID,
+		reward.RefereeBonus,
+		reward.ReferrerBonus,
+		reward.ReferrerAfterTasks,
+		reward.ReferrerPaidAt,
+		time.Now(),
+	).Scan(&reward.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+// ClaimReferrerReward отмечает бонус реферера начисленным, если приглашённый
+// выполнил нужное число заданий. Возвращает nil, если бонус не положен, задержан или уже начислен.
+// Отметка и проверка выполняются одним запросом, поэтому бонус начисляется один раз.
+func (s *Storage) ClaimReferrerReward(ctx context.Context, refereeID uuid.UUID) (*entities.ReferralReward, error) {
+	const query = `
+		UPDATE referral_rewards r
+		SET referrer_paid_at = $2
+		WHERE r.referee_id = $1
+		  AND r.referrer_paid_at IS NULL
+		  AND NOT r.held
+		  AND (
+		      SELECT COUNT(*) FROM user_tasks ut
+		      WHERE ut.user_id = r.referee_id AND ut.revoked_at IS NULL
+		  ) >= r.referrer_after_tasks
+		RETURNING r.referee_id, r.referrer_id, r.referee_bonus, r.referrer_bonus,
+		          r.referrer_after_tasks, r.referrer_paid_at, r.created_at
+	`
+
+	var reward entities.ReferralReward
+	err := s.conn(ctx).QueryRow(ctx, query, refereeID, time.Now()).Scan(
+		&reward.RefereeID,
+		&reward.ReferrerID,
+		&reward.RefereeBonus,
+		&reward.ReferrerBonus,
+		&reward.ReferrerAfterTasks,
+		&reward.ReferrerPaidAt,
+		&reward.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+// GetDueReferrerReward возвращает бонусы приглашения, если бонус рефереру
+// уже можно начислить: он не начислен, не задержан и задания выполнены.
+// Иначе nil без ошибки.
+func (s *Storage) GetDueReferrerReward(ctx context.Context, refereeID uuid.UUID) (*entities.ReferralReward, error) {
+	const query = `
+		SELECT r.referee_id, r.referrer_id, r.referee_bonus, r.referrer_bonus,
+		       r.referrer_after_tasks, r.referrer_paid_at, r.created_at
+		FROM referral_rewards r
+		WHERE r.referee_id = $1
+		  AND r.referrer_paid_at IS NULL
+		  AND NOT r.held
+		  AND (
+		      SELECT COUNT(*) FROM user_tasks ut
+		      WHERE ut.user_id = r.referee_id AND ut.revoked_at IS NULL
+		  ) >= r.referrer_after_tasks
+	`
+
+	var reward entities.ReferralReward
+	err := s.conn(ctx).QueryRow(ctx, query, refereeID).Scan(
+		&reward.RefereeID,
+		&reward.ReferrerID,
+		&reward.RefereeBonus,
+		&reward.ReferrerBonus,
+		&reward.ReferrerAfterTasks,
+		&reward.ReferrerPaidAt,
+		&reward.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+// ClaimRefereeReward отмечает бонус приглашённого начисленным и возвращает его размер.
+// Задержанный или уже начисленный бонус возвращает 0.
+func (s *Storage) ClaimRefereeReward(ctx context.Context, refereeID uuid.UUID) (int, error) {
+	const query = `
+		UPDATE referral_rewards
+		SET referee_paid_at = $2
+		WHERE referee_id = $1 AND referee_paid_at IS NULL AND NOT held AND referee_bonus > 0
+		RETURNING referee_bonus
+	`
+
+	var bonus int
+	if err := s.conn(ctx).QueryRow(ctx, query, refereeID, time.Now()).Scan(&bonus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return bonus, nil
+}
+
+// SetReferralRewardHeld задерживает или освобождает ещё не начисленные бонусы приглашения
+func (s *Storage) SetReferralRewardHeld(ctx context.Context, refereeID uuid.UUID, held bool) error {
+	const query = `UPDATE referral_rewards SET held = $2 WHERE referee_id = $1`
+
+	_, err := s.conn(ctx).Exec(ctx, query, refereeID, held)
+	return err
+}
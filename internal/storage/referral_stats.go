@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// GetReferralSummary возвращает сводку по прямым приглашённым реферера.
+// Периоды считаются от переданных границ, заработок — по журналу баллов реферера.
+func (s *Storage) GetReferralSummary(ctx context.Context, referrerID uuid.UUID, day, week, month time.Time) (*entities.ReferralSummary, error) {
+	const query = `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE EXISTS (
+		           SELECT 1 FROM user_tasks ut
+		           WHERE ut.user_id = u.id AND ut.revoked_at IS NULL
+		       )),
+		       COUNT(*) FILTER (WHERE u.referred_at >= $2),
+		       COUNT(*) FILTER (WHERE u.referred_at >= $3),
+		       COUNT(*) FILTER (WHERE u.referred_at >= $4),
+		       (
+		           SELECT COALESCE(SUM(l.amount), 0)
+		           FROM points_ledger l
+		           WHERE l.user_id = $1 AND l.reason_type IN ('referrer_bonus', 'referral_commission', 'referral_commission_reversed')
+		       )
+		FROM users u
+		WHERE u.referrer_id = $1
+	`
+
+	var summary entities.ReferralSummary
+	err := s.conn(ctx).QueryRow(ctx, query, referrerID, day, week, month).Scan(
+		&summary.Total,
+		&summary.Active,
+		&summary.LastDay,
+		&summary.LastWeek,
+		&summary.LastMonth,
+		&summary.TotalEarned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// GetRefereesPage возвращает страницу прямых приглашённых реферера, новые первыми
+func (s *Storage) GetRefereesPage(ctx context.Context, referrerID uuid.UUID, filter entities.RefereeFilter) (*entities.RefereePage, error) {
+	const query = `
+		SELECT u.id, u.username, u.referred_at,
+		       (
+		           SELECT COUNT(*) FROM user_tasks ut
+		           WHERE ut.user_id = u.id AND ut.revoked_at IS NULL
+		       ),
+		       (
+		           SELECT COALESCE(SUM(l.amount), 0) FROM points_ledger l
+		           WHERE l.user_id = $1 AND l.reason_type = 'referrer_bonus' AND l.reference_id = u.id
+		       ) + (
+		           SELECT COALESCE(SUM(c.amount), 0) FROM referral_commissions c
+		           WHERE c.beneficiary_id = $1 AND c.source_user_id = u.id AND c.reversed_at IS NULL
+		       )
+		FROM users u
+		WHERE u.referrer_id = $1
+		  AND ($2::timestamp IS NULL OR (u.referred_at, u.id) < ($2::timestamp, $3::uuid))
+		ORDER BY u.referred_at DESC, u.id DESC
+		LIMIT $4
+	`
+
+	var (
+		afterAt *time.Time
+		afterID *uuid.UUID
+	)
+	if filter.After != nil {
+		afterAt, afterID = &filter.After.ReferredAt, &filter.After.ID
+	}
+
+	// читаем на одну запись больше, чтобы понять, есть ли следующая страница
+	rows, err := s.conn(ctx).Query(ctx, query, referrerID, afterAt, afterID, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &entities.RefereePage{Referees: []entities.Referee{}}
+	for rows.Next() {
+		var r entities.Referee
+		if err := rows.Scan(
+			&r.UserID,
+			&r.Username,
+			&r.ReferredAt,
+			&r.CompletedTasks,
+			&r.Earned,
+		); err != nil {
+			return nil, err
+		}
+		page.Referees = append(page.Referees, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Referees) > filter.Limit {
+		page.Referees = page.Referees[:filter.Limit]
+		last := page.Referees[len(page.Referees)-1]
+		page.Next = &entities.RefereePosition{ReferredAt: last.ReferredAt, ID: last.UserID}
+	}
+
+	return page, nil
+}
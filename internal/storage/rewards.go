@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RewardModel — структура для таблицы rewards
+type RewardModel struct {
+	ID           uuid.UUID
+	Code         string
+	Title        string
+	Description  string
+	Price        int
+	Stock        *int
+	PerUserLimit *int
+	Active       bool
+	CreatedAt    time.Time
+}
+
+// RedemptionModel — структура для таблицы reward_redemptions
+type RedemptionModel struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	RewardID    uuid.UUID
+	RewardCode  string
+	Price       int
+	Status      string
+	ProcessedBy *uuid.UUID
+	Note        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+const rewardColumns = `id, code, title, COALESCE(description, ''), price, stock, per_user_limit, active, created_at`
+
+// CreateReward добавляет награду в магазин
+func (s *Storage) CreateReward(ctx context.Context, reward entities.Reward) (*entities.Reward, error) {
+	const query = `
+		INSERT INTO rewards (code, title, description, price, stock, per_user_limit, active, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)
+		RETURNING ` + rewardColumns
+
+	row := s.conn(ctx).QueryRow(ctx, query,
+		reward.Code,
+		reward.Title,
+		reward.Description,
+		reward.Price,
+		reward.Stock,
+		reward.PerUserLimit,
+		reward.Active,
+		time.Now(),
+	)
+
+	created, err := scanReward(row)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, entities.ErrRewardAlreadyExists
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListRewards возвращает награды магазина, при activeOnly — только доступные для покупки
+func (s *Storage) ListRewards(ctx context.Context, activeOnly bool) ([]*entities.Reward, error) {
+	const query = `
+		SELECT ` + rewardColumns + `
+		FROM rewards
+		WHERE NOT $1::bool OR active
+		ORDER BY price, code
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rewards := []*entities.Reward{}
+	for rows.Next() {
+		r, err := scanReward(rows)
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, r)
+	}
+
+	return rewards, rows.Err()
+}
+
+// ReserveRewardStock уменьшает остаток активной награды на единицу и возвращает её.
+// Строка награды блокируется до конца транзакции, поэтому остаток не уйдёт в минус.
+func (s *Storage) ReserveRewardStock(ctx context.Context, rewardID uuid.UUID) (*entities.Reward, error) {
+	const query = `
+		UPDATE rewards
+		SET stock = stock - 1
+		WHERE id = $1 AND active AND (stock IS NULL OR stock > 0)
+		RETURNING ` + rewardColumns
+
+	reward, err := scanReward(s.conn(ctx).QueryRow(ctx, query, rewardID))
+	if err == nil {
+		return reward, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// награды нет, она снята с продажи или закончилась
+	const activeQuery = `SELECT active FROM rewards WHERE id = $1`
+	var active bool
+	if err := s.conn(ctx).QueryRow(ctx, activeQuery, rewardID).Scan(&active); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrRewardNotFound
+		}
+		return nil, err
+	}
+	if !active {
+		return nil, entities.ErrRewardNotFound
+	}
+
+	return nil, entities.ErrRewardOutOfStock
+}
+
+// ReleaseRewardStock возвращает единицу на склад, если остаток ограничен
+func (s *Storage) ReleaseRewardStock(ctx context.Context, rewardID uuid.UUID) error {
+	const query = `UPDATE rewards SET stock = stock + 1 WHERE id = $1 AND stock IS NOT NULL`
+
+	_, err := s.conn(ctx).Exec(ctx, query, rewardID)
+	return err
+}
+
+// CountUserRedemptions возвращает число неотменённых заказов награды пользователем
+func (s *Storage) CountUserRedemptions(ctx context.Context, userID, rewardID uuid.UUID) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM reward_redemptions
+		WHERE user_id = $1 AND reward_id = $2 AND status <> 'cancelled'
+	`
+
+	var count int
+	if err := s.conn(ctx).QueryRow(ctx, query, userID, rewardID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreateRedemption сохраняет заказ награды
+func (s *Storage) CreateRedemption(ctx context.Context, redemption entities.Redemption) (*entities.Redemption, error) {
+	const query = `
+		INSERT INTO reward_redemptions (id, user_id, reward_id, price, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING created_at, updated_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		redemption.ID,
+		redemption.UserID,
+		redemption.RewardID,
+		redemption.Price,
+		string(redemption.Status),
+		time.Now(),
+	).Scan(&redemption.CreatedAt, &redemption.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redemption, nil
+}
+
+// ListRedemptions возвращает заказы по фильтру, новые первыми
+func (s *Storage) ListRedemptions(ctx context.Context, filter entities.RedemptionFilter) ([]*entities.Redemption, error) {
+	const query = `
+		SELECT rr.id, rr.user_id, rr.reward_id, r.code, rr.price, rr.status,
+		       rr.processed_by, COALESCE(rr.note, ''), rr.created_at, rr.updated_at
+		FROM reward_redemptions rr
+		JOIN rewards r ON r.id = rr.reward_id
+		WHERE ($1::uuid IS NULL OR rr.user_id = $1::uuid)
+		  AND ($2::text = '' OR rr.status = $2::text)
+		ORDER BY rr.created_at DESC, rr.id DESC
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, filter.UserID, string(filter.Status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := []*entities.Redemption{}
+	for rows.Next() {
+		var m RedemptionModel
+		if err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.RewardID,
+			&m.RewardCode,
+			&m.Price,
+			&m.Status,
+			&m.ProcessedBy,
+			&m.Note,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		redemptions = append(redemptions, mapRedemptionModelToEntity(&m))
+	}
+
+	return redemptions, rows.Err()
+}
+
+// DecideRedemption переводит ожидающий заказ в итоговый статус.
+// Обработанный заказ не меняется и возвращает ErrRedemptionNotPending.
+func (s *Storage) DecideRedemption(ctx context.Context, decision entities.RedemptionDecision) (*entities.Redemption, error) {
+	const query = `
+		UPDATE reward_redemptions rr
+		SET status       = $2,
+		    processed_by = $3,
+		    note         = NULLIF($4, ''),
+		    updated_at   = $5
+		FROM rewards r
+		WHERE rr.id = $1 AND rr.status = 'pending' AND r.id = rr.reward_id
+		RETURNING rr.id, rr.user_id, rr.reward_id, r.code, rr.price, rr.status,
+		          rr.processed_by, COALESCE(rr.note, ''), rr.created_at, rr.updated_at
+	`
+
+	var m RedemptionModel
+	err := s.conn(ctx).QueryRow(ctx, query,
+		decision.RedemptionID,
+		string(decision.Status),
+		decision.AdminID,
+		decision.Note,
+		time.Now(),
+	).Scan(
+		&m.ID,
+		&m.UserID,
+		&m.RewardID,
+		&m.RewardCode,
+		&m.Price,
+		&m.Status,
+		&m.ProcessedBy,
+		&m.Note,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+
+		const existsQuery = `SELECT 1 FROM reward_redemptions WHERE id = $1`
+		var tmp int
+		if err := s.conn(ctx).QueryRow(ctx, existsQuery, decision.RedemptionID).Scan(&tmp); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, entities.ErrRedemptionNotFound
+			}
+			return nil, err
+		}
+		return nil, entities.ErrRedemptionNotPending
+	}
+
+	return mapRedemptionModelToEntity(&m), nil
+}
+
+// scanReward читает награду из строки с колонками rewardColumns
+func scanReward(row pgx.Row) (*entities.Reward, error) {
+	var m RewardModel
+	if err := row.Scan(
+		&m.ID,
+		&m.Code,
+		&m.Title,
+		&m.Description,
+		&m.Price,
+		&m.Stock,
+		&m.PerUserLimit,
+		&m.Active,
+		&m.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &entities.Reward{
+		ID:           m.ID,
+		Code:         m.Code,
+		Title:        m.Title,
+		Description:  m.Description,
+		Price:        m.Price,
+		Stock:        m.Stock,
+		PerUserLimit: m.PerUserLimit,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+	}, nil
+}
+
+// mapRedemptionModelToEntity конвертирует модель базы в сущность
+func mapRedemptionModelToEntity(m *RedemptionModel) *entities.Redemption {
+	return &entities.Redemption{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		RewardID:    m.RewardID,
+		RewardCode:  m.RewardCode,
+		Price:       m.Price,
+		Status:      entities.RedemptionStatus(m.Status),
+		ProcessedBy: m.ProcessedBy,
+		Note:        m.Note,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
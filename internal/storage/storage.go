@@ -2,10 +2,19 @@ package storage
 
 import (
 	"context"
+	"errors"
 
+	"service-boilerplate-go/pkg/pgdb"
+
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const pgUniqueViolation = "23505"
+
+// referralCodeConstraint — уникальный индекс кодов приглашения пользователей
+const referralCodeConstraint = "uq_users_referral_code"
+
 type Logger interface {
 	Error(ctx context.Context, msg string)
 }
@@ -16,5 +25,25 @@ type Storage struct {
 }
 
 func New(logger Logger, db *pgxpool.Pool) *Storage {
-	return &Storage{db: db}
+	return &Storage{logger: logger, db: db}
+}
+
+// conn возвращает транзакцию из контекста, если она открыта, иначе пул
+func (s *Storage) conn(ctx context.Context) pgdb.Querier {
+	if tx, ok := pgdb.TxFromContext(ctx); ok {
+		return tx
+	}
+	return s.db
+}
+
+// isUniqueViolation проверяет, что ошибка — нарушение уникальности
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+// isConstraintViolation проверяет, что ошибка — нарушение уникальности указанного ограничения
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
 }
@@ -13,26 +13,35 @@ import (
 
 // TaskModel — структура для работы с таблицей tasks
 type TaskModel struct {
-	ID           uuid.UUID
-	Code         string
-	Description  string
-	RewardPoints int
-	CreatedAt    time.Time
+	ID            uuid.UUID
+	Code          string
+	Description   string
+	RewardPoints  int
+	TargetCount   int
+	ProgressEvent *string
+	CompletionCap *int
+	Completions   int
+	CreatedAt     time.Time
 }
 
 // UserTaskModel — структура для таблицы user_tasks
 type UserTaskModel struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	TaskID      uuid.UUID
-	CompletedAt time.Time
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	TaskID          uuid.UUID
+	Points          int
+	CompletedAt     time.Time
+	RevokedAt       *time.Time
+	RevokedBy       *uuid.UUID
+	RevokeReason    string
+	AllowRecomplete bool
 }
 
 // Проверяем, существует ли задача
 func (s *Storage) IsTaskExists(ctx context.Context, taskID uuid.UUID) (bool, error) {
 	const query = `SELECT 1 FROM tasks WHERE id = $1`
 	var tmp int
-	err := s.db.QueryRow(ctx, query, taskID).Scan(&tmp)
+	err := s.conn(ctx).QueryRow(ctx, query, taskID).Scan(&tmp)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -42,11 +51,136 @@ func (s *Storage) IsTaskExists(ctx context.Context, taskID uuid.UUID) (bool, err
 	return true, nil
 }
 
-// Проверяем, выполнена ли задача пользователем
+// GetTaskByID возвращает задачу по UUID
+func (s *Storage) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entities.Task, error) {
+	const query = `
+		SELECT id, code, COALESCE(description, ''), reward_points, target_count, progress_event,
+		       completion_cap, completions_count, created_at
+		FROM tasks
+		WHERE id = $1
+	`
+
+	var m TaskModel
+	err := s.conn(ctx).QueryRow(ctx, query, taskID).Scan(
+		&m.ID,
+		&m.Code,
+		&m.Description,
+		&m.RewardPoints,
+		&m.TargetCount,
+		&m.ProgressEvent,
+		&m.CompletionCap,
+		&m.Completions,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return mapTaskModelToEntity(&m), nil
+}
+
+// GetTaskByCode возвращает задачу по системному имени
+func (s *Storage) GetTaskByCode(ctx context.Context, code string) (*entities.Task, error) {
+	const query = `
+		SELECT id, code, COALESCE(description, ''), reward_points, target_count, progress_event,
+		       completion_cap, completions_count, created_at
+		FROM tasks
+		WHERE code = $1
+	`
+
+	var m TaskModel
+	err := s.conn(ctx).QueryRow(ctx, query, code).Scan(
+		&m.ID,
+		&m.Code,
+		&m.Description,
+		&m.RewardPoints,
+		&m.TargetCount,
+		&m.ProgressEvent,
+		&m.CompletionCap,
+		&m.Completions,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return mapTaskModelToEntity(&m), nil
+}
+
+// GetTasksByProgressEvent возвращает задачи, прогресс которых двигает событие
+func (s *Storage) GetTasksByProgressEvent(ctx context.Context, event entities.ProgressEvent) ([]*entities.Task, error) {
+	const query = `
+		SELECT id, code, COALESCE(description, ''), reward_points, target_count, progress_event,
+		       completion_cap, completions_count, created_at
+		FROM tasks
+		WHERE progress_event = $1
+		ORDER BY created_at
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, string(event))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*entities.Task
+	for rows.Next() {
+		var m TaskModel
+		if err := rows.Scan(
+			&m.ID,
+			&m.Code,
+			&m.Description,
+			&m.RewardPoints,
+			&m.TargetCount,
+			&m.ProgressEvent,
+			&m.CompletionCap,
+			&m.Completions,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, mapTaskModelToEntity(&m))
+	}
+
+	return tasks, rows.Err()
+}
+
+// mapTaskModelToEntity конвертирует модель базы в сущность
+func mapTaskModelToEntity(m *TaskModel) *entities.Task {
+	t := &entities.Task{
+		ID:            m.ID,
+		Code:          m.Code,
+		Title:         m.Code,
+		Description:   m.Description,
+		RewardPoints:  m.RewardPoints,
+		TargetCount:   m.TargetCount,
+		CompletionCap: m.CompletionCap,
+		Completions:   m.Completions,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.ProgressEvent != nil {
+		t.ProgressEvent = entities.ProgressEvent(*m.ProgressEvent)
+	}
+	return t
+}
+
+// Проверяем, выполнена ли задача пользователем.
+// Отозванное выполнение считается выполненным, если повторное выполнение не разрешено.
 func (s *Storage) IsTaskCompleted(ctx context.Context, userID, taskID uuid.UUID) (bool, error) {
-	const query = `SELECT 1 FROM user_tasks WHERE user_id = $1 AND task_id = $2`
+	const query = `
+		SELECT 1 FROM user_tasks
+		WHERE user_id = $1 AND task_id = $2
+		  AND (revoked_at IS NULL OR NOT allow_recomplete)
+		LIMIT 1
+	`
 	var tmp int
-	err := s.db.QueryRow(ctx, query, userID, taskID).Scan(&tmp)
+	err := s.conn(ctx).QueryRow(ctx, query, userID, taskID).Scan(&tmp)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -56,50 +190,200 @@ func (s *Storage) IsTaskCompleted(ctx context.Context, userID, taskID uuid.UUID)
 	return true, nil
 }
 
-// MarkTaskCompleted вставляет запись о выполненной задаче и обновляет баллы пользователя.
-// Возвращает ID созданной записи в user_tasks.
-func (s *Storage) MarkTaskCompleted(ctx context.Context, userID, taskID uuid.UUID) (uuid.UUID, error) {
+// MarkTaskCompleted вставляет запись о выполненной задаче с рассчитанным начислением
+// и возвращает ID созданной записи в user_tasks. Баланс пользователя не меняется:
+// начисление проводится через журнал баллов.
+// Повторное выполнение отсекается уникальным индексом и возвращает ErrTaskAlreadyCompleted.
+// Слот глобального лимита занимается отдельно, через ReserveTaskSlot.
+func (s *Storage) MarkTaskCompleted(ctx context.Context, userID, taskID uuid.UUID, reward entities.TaskReward) (uuid.UUID, error) {
 	const insertQuery = `
-		INSERT INTO user_tasks (user_id, task_id, completed_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO user_tasks (user_id, task_id, base_points, multiplier, multiplier_event_id, points, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, task_id) WHERE revoked_at IS NULL DO NOTHING
 		RETURNING id
 	`
 
 	var userTaskID uuid.UUID
-	err := s.db.QueryRow(ctx, insertQuery, userID, taskID, time.Now()).Scan(&userTaskID)
+	err := s.conn(ctx).QueryRow(ctx, insertQuery,
+		userID,
+		taskID,
+		reward.BasePoints,
+		reward.Multiplier,
+		reward.EventID,
+		reward.Points,
+		time.Now(),
+	).Scan(&userTaskID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, entities.ErrTaskAlreadyCompleted
+		}
 		return uuid.Nil, err
 	}
 
-	// обновление баллов пользователя
-	const updateQuery = `
-		UPDATE users 
-		SET points = points + (SELECT reward_points FROM tasks WHERE id = $1)
-		WHERE id = $2
+	return userTaskID, nil
+}
+
+// ReserveTaskSlot занимает слот глобального лимита задачи. Условный UPDATE держит
+// блокировку строки задачи до конца транзакции, поэтому конкурентные выполнения
+// не превысят лимит. Для задач без лимита не вызывается: счётчик ведётся только у задач с лимитом.
+// Возвращает ErrTaskCapReached, если лимит исчерпан.
+func (s *Storage) ReserveTaskSlot(ctx context.Context, taskID uuid.UUID) error {
+	const query = `
+		UPDATE tasks
+		SET completions_count = completions_count + 1
+		WHERE id = $1 AND completion_cap IS NOT NULL AND completions_count < completion_cap
 	`
-	_, err = s.db.Exec(ctx, updateQuery, taskID, userID)
+
+	tag, err := s.conn(ctx).Exec(ctx, query, taskID)
 	if err != nil {
-		return uuid.Nil, err
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entities.ErrTaskCapReached
 	}
 
-	return userTaskID, nil
+	return nil
+}
+
+// RevokeUserTask помечает выполнение отозванным и возвращает его.
+// Запись не удаляется, чтобы сохранить историю для аудита. Слот лимита освобождает ReleaseTaskSlot.
+func (s *Storage) RevokeUserTask(ctx context.Context, revocation entities.TaskRevocation) (*entities.UserTask, error) {
+	const query = `
+		UPDATE user_tasks
+		SET revoked_at       = $2,
+		    revoked_by       = $3,
+		    revoke_reason    = $4,
+		    allow_recomplete = $5
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING id, user_id, task_id, points, completed_at,
+		          revoked_at, revoked_by, revoke_reason, allow_recomplete
+	`
+
+	var (
+		m      UserTaskModel
+		reason *string
+	)
+	err := s.conn(ctx).QueryRow(ctx, query,
+		revocation.UserTaskID,
+		time.Now(),
+		revocation.AdminID,
+		revocation.Reason,
+		revocation.AllowRecomplete,
+	).Scan(
+		&m.ID,
+		&m.UserID,
+		&m.TaskID,
+		&m.Points,
+		&m.CompletedAt,
+		&m.RevokedAt,
+		&m.RevokedBy,
+		&reason,
+		&m.AllowRecomplete,
+	)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+
+		// выполнения нет или оно уже отозвано
+		const existsQuery = `SELECT 1 FROM user_tasks WHERE id = $1`
+		var tmp int
+		if err := s.conn(ctx).QueryRow(ctx, existsQuery, revocation.UserTaskID).Scan(&tmp); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, entities.ErrUserTaskNotFound
+			}
+			return nil, err
+		}
+		return nil, entities.ErrTaskAlreadyRevoked
+	}
+
+	if reason != nil {
+		m.RevokeReason = *reason
+	}
+
+	return mapUserTaskModelToEntity(&m), nil
+}
+
+// ReleaseTaskSlot освобождает слот глобального лимита задачи после отзыва выполнения.
+// У задач без лимита счётчик не ведётся, и строка задачи не меняется.
+func (s *Storage) ReleaseTaskSlot(ctx context.Context, taskID uuid.UUID) error {
+	const query = `
+		UPDATE tasks
+		SET completions_count = completions_count - 1
+		WHERE id = $1 AND completion_cap IS NOT NULL AND completions_count > 0
+	`
+
+	_, err := s.conn(ctx).Exec(ctx, query, taskID)
+	return err
+}
+
+// ResetTaskProgress обнуляет прогресс пользователя по задаче со счётчиком
+func (s *Storage) ResetTaskProgress(ctx context.Context, userID, taskID uuid.UUID) error {
+	const query = `DELETE FROM user_task_progress WHERE user_id = $1 AND task_id = $2`
+
+	_, err := s.conn(ctx).Exec(ctx, query, userID, taskID)
+	return err
+}
+
+// mapUserTaskModelToEntity конвертирует модель базы в сущность
+func mapUserTaskModelToEntity(m *UserTaskModel) *entities.UserTask {
+	return &entities.UserTask{
+		ID:              m.ID,
+		UserID:          m.UserID,
+		TaskID:          m.TaskID,
+		Points:          m.Points,
+		CompletedAt:     m.CompletedAt,
+		RevokedAt:       m.RevokedAt,
+		RevokedBy:       m.RevokedBy,
+		RevokeReason:    m.RevokeReason,
+		AllowRecomplete: m.AllowRecomplete,
+	}
+}
+
+// IncrementTaskProgress увеличивает прогресс пользователя по задаче, не выходя за target.
+// Возвращает прогресс после обновления.
+func (s *Storage) IncrementTaskProgress(ctx context.Context, userID, taskID uuid.UUID, delta, target int) (int, error) {
+	const query = `
+		INSERT INTO user_task_progress (user_id, task_id, progress, updated_at)
+		VALUES ($1, $2, LEAST($3::int, $4::int), $5)
+		ON CONFLICT (user_id, task_id) DO UPDATE
+		SET progress   = LEAST(user_task_progress.progress + EXCLUDED.progress, $4::int),
+		    updated_at = EXCLUDED.updated_at
+		RETURNING progress
+	`
+
+	var progress int
+	err := s.conn(ctx).QueryRow(ctx, query, userID, taskID, delta, target, time.Now()).Scan(&progress)
+	if err != nil {
+		return 0, err
+	}
+
+	return progress, nil
 }
 
 func (s *Storage) UpdateUserReferrer(ctx context.Context, userID, referrerID uuid.UUID) error {
 	const query = `
 		UPDATE users
-		SET referrer_id = $1
-		WHERE id = $2
+		SET referrer_id = $1, referred_at = $3
+		WHERE id = $2 AND referrer_id IS NULL
 	`
 
-	tag, err := s.db.Exec(ctx, query, referrerID, userID)
+	tag, err := s.conn(ctx).Exec(ctx, query, referrerID, userID, time.Now())
 	if err != nil {
 		return err
 	}
 
 	if tag.RowsAffected() == 0 {
 		// Если обновление не затронуло ни одной строки — пользователя нет
-		return entities.ErrUserNotFound
+		// или реферер уже установлен параллельным запросом
+		exists, err := s.IsUserExists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entities.ErrUserNotFound
+		}
+		return entities.ErrReferrerAlreadySet
 	}
 
 	return nil
@@ -122,7 +406,7 @@ func (s *Storage) MarkTaskMetadata(ctx context.Context, userTaskID uuid.UUID, me
 		batch.Queue(query, userTaskID, key, value)
 	}
 
-	br := s.db.SendBatch(ctx, batch)
+	br := s.conn(ctx).SendBatch(ctx, batch)
 	defer func() {
 		if err := br.Close(); err != nil {
 			ctx = context.WithoutCancel(ctx)
@@ -132,7 +416,10 @@ func (s *Storage) MarkTaskMetadata(ctx context.Context, userTaskID uuid.UUID, me
 
 	for range metadata {
 		if _, err := br.Exec(); err != nil {
-			return entities.ErrTaskMetadataAlreadyExists
+			if isUniqueViolation(err) {
+				return entities.ErrTaskMetadataAlreadyExists
+			}
+			return err
 		}
 	}
 
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+)
+
+// LockUsers блокирует строки пользователей до конца транзакции.
+// Строки блокируются в порядке id, поэтому встречные переводы не дают взаимной блокировки.
+// Возвращает ID найденных пользователей.
+func (s *Storage) LockUsers(ctx context.Context, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	const query = `
+		SELECT id
+		FROM users
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	rows, err := s.conn(ctx).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locked []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		locked = append(locked, id)
+	}
+
+	return locked, rows.Err()
+}
+
+// IsUserRegisteredBefore сообщает, зарегистрирован ли пользователь раньше момента at.
+// Сравнение выполняется в базе, где хранится время регистрации.
+func (s *Storage) IsUserRegisteredBefore(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error) {
+	const query = `SELECT created_at < $2 FROM users WHERE id = $1`
+
+	var before bool
+	if err := s.conn(ctx).QueryRow(ctx, query, userID, at).Scan(&before); err != nil {
+		return false, err
+	}
+
+	return before, nil
+}
+
+// SumUserTransfersSince возвращает сумму исходящих переводов пользователя начиная с since
+func (s *Storage) SumUserTransfersSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	const query = `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM points_transfers
+		WHERE sender_id = $1 AND created_at >= $2
+	`
+
+	var sum int
+	if err := s.conn(ctx).QueryRow(ctx, query, userID, since).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+// CreateTransfer сохраняет перевод
+func (s *Storage) CreateTransfer(ctx context.Context, transfer entities.PointsTransfer) (*entities.PointsTransfer, error) {
+	const query = `
+		INSERT INTO points_transfers (id, sender_id, recipient_id, amount, note, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		RETURNING created_at
+	`
+
+	err := s.conn(ctx).QueryRow(ctx, query,
+		transfer.ID,
+		transfer.SenderID,
+		transfer.RecipientID,
+		transfer.Amount,
+		transfer.Note,
+		time.Now(),
+	).Scan(&transfer.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
@@ -18,27 +18,55 @@ type UserModel struct {
 	PasswordHash string
 	Points       int
 	ReferrerID   *uuid.UUID
+	ReferralCode string
+	Role         string
 	CreatedAt    time.Time
 }
 
-// CreateUser создаёт нового пользователя и возвращает сущность
-func (s *Storage) CreateUser(ctx context.Context, username, passwordHash string) (*entities.User, error) {
+// CreateUser создаёт нового пользователя и возвращает сущность.
+// Реферер из signup устанавливается той же вставкой; его существование проверяет внешний ключ.
+// Если код приглашения уже занят, возвращает ErrReferralCodeTaken.
+func (s *Storage) CreateUser(ctx context.Context, username, passwordHash, referralCode string, signup entities.SignupInfo) (*entities.User, error) {
 	const query = `
-		INSERT INTO users (id, username, password_hash, points, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, username, password_hash, points, referrer_id, created_at
+		INSERT INTO users (
+			id, username, password_hash, points, referral_code, created_at,
+			signup_ip, signup_user_agent, utm_source, utm_campaign, referrer_id, referred_at
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, $6,
+			NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''),
+			$11, CASE WHEN $11::uuid IS NULL THEN NULL ELSE $6 END
+		)
+		RETURNING id, username, password_hash, points, referrer_id, referral_code, role, created_at
 	`
 
 	var m UserModel
-	err := s.db.QueryRow(ctx, query, uuid.New(), username, passwordHash, int64(0), time.Now()).Scan(
+	err := s.conn(ctx).QueryRow(ctx, query,
+		uuid.New(),
+		username,
+		passwordHash,
+		int64(0),
+		referralCode,
+		time.Now(),
+		signup.IP,
+		signup.UserAgent,
+		signup.UTMSource,
+		signup.UTMCampaign,
+		signup.ReferrerID,
+	).Scan(
 		&m.ID,
 		&m.Username,
 		&m.PasswordHash,
 		&m.Points,
 		&m.ReferrerID,
+		&m.ReferralCode,
+		&m.Role,
 		&m.CreatedAt,
 	)
 	if err != nil {
+		if isConstraintViolation(err, referralCodeConstraint) {
+			return nil, entities.ErrReferralCodeTaken
+		}
 		return nil, err
 	}
 
@@ -48,18 +76,20 @@ func (s *Storage) CreateUser(ctx context.Context, username, passwordHash string)
 // GetUserByUsername возвращает сущность пользователя по username
 func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
 	const query = `
-		SELECT id, username, password_hash, points, referrer_id, created_at
+		SELECT id, username, password_hash, points, referrer_id, referral_code, role, created_at
 		FROM users
 		WHERE username = $1
 	`
 
 	var m UserModel
-	err := s.db.QueryRow(ctx, query, username).Scan(
+	err := s.conn(ctx).QueryRow(ctx, query, username).Scan(
 		&m.ID,
 		&m.Username,
 		&m.PasswordHash,
 		&m.Points,
 		&m.ReferrerID,
+		&m.ReferralCode,
+		&m.Role,
 		&m.CreatedAt,
 	)
 	if err != nil {
@@ -75,7 +105,7 @@ func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*enti
 func (s *Storage) IsUserExists(ctx context.Context, id uuid.UUID) (bool, error) {
 	const query = `SELECT 1 FROM users WHERE id = $1`
 	var tmp int
-	err := s.db.QueryRow(ctx, query, id).Scan(&tmp)
+	err := s.conn(ctx).QueryRow(ctx, query, id).Scan(&tmp)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -89,18 +119,20 @@ func (s *Storage) IsUserExists(ctx context.Context, id uuid.UUID) (bool, error)
 // GetUserByID возвращает сущность пользователя по UUID
 func (s *Storage) GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	const query = `
-		SELECT id, username, password_hash, points, referrer_id, created_at
+		SELECT id, username, password_hash, points, referrer_id, referral_code, role, created_at
 		FROM users
 		WHERE id = $1
 	`
 
 	var m UserModel
-	err := s.db.QueryRow(ctx, query, id).Scan(
+	err := s.conn(ctx).QueryRow(ctx, query, id).Scan(
 		&m.ID,
 		&m.Username,
 		&m.PasswordHash,
 		&m.Points,
 		&m.ReferrerID,
+		&m.ReferralCode,
+		&m.Role,
 		&m.CreatedAt,
 	)
 	if err != nil {
@@ -113,75 +145,90 @@ func (s *Storage) GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User
 	return mapUserModelToEntity(&m), nil
 }
 
-// mapUserModelToEntity конвертирует модель базы в сущность
-func mapUserModelToEntity(m *UserModel) *entities.User {
-	return &entities.User{
-		ID:         m.ID,
-		Username:   m.Username,
-		Password:   m.PasswordHash,
-		Points:     m.Points,
-		ReferrerID: m.ReferrerID,
-		CreatedAt:  m.CreatedAt,
-	}
-}
-
-type UserLeaderBoardRow struct {
-	ID       uuid.UUID
-	Username string
-	Points   int
-}
-
-// GetUsersLeaderboard возвращает топ пользователей по points с пагинацией
-func (s *Storage) GetUsersLeaderboard(ctx context.Context, limit, offset int) (entities.UsersLeaderboard, error) {
+// GetUserByReferralCode возвращает пользователя по нормализованному коду приглашения
+func (s *Storage) GetUserByReferralCode(ctx context.Context, code string) (*entities.User, error) {
 	const query = `
-		SELECT id, username, points
+		SELECT id, username, password_hash, points, referrer_id, referral_code, role, created_at
 		FROM users
-		ORDER BY points DESC
-		LIMIT $1 OFFSET $2
+		WHERE referral_code = $1
 	`
 
-	rows, err := s.db.Query(ctx, query, limit, offset)
+	var m UserModel
+	err := s.conn(ctx).QueryRow(ctx, query, code).Scan(
+		&m.ID,
+		&m.Username,
+		&m.PasswordHash,
+		&m.Points,
+		&m.ReferrerID,
+		&m.ReferralCode,
+		&m.Role,
+		&m.CreatedAt,
+	)
 	if err != nil {
-		return entities.UsersLeaderboard{}, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrReferralCodeNotFound
+		}
+		return nil, err
 	}
-	defer rows.Close()
 
-	// сканируем в модель БД
-	var models []UserModel
-	for rows.Next() {
-		var m UserModel
-		if err := rows.Scan(&m.ID, &m.Username, &m.Points); err != nil {
-			return entities.UsersLeaderboard{}, err
+	return mapUserModelToEntity(&m), nil
+}
+
+// UpdateUserReferralCode заменяет код приглашения пользователя.
+// Если код занят другим пользователем, возвращает ErrReferralCodeTaken.
+func (s *Storage) UpdateUserReferralCode(ctx context.Context, userID uuid.UUID, code string) error {
+	const query = `UPDATE users SET referral_code = $2 WHERE id = $1`
+
+	tag, err := s.conn(ctx).Exec(ctx, query, userID, code)
+	if err != nil {
+		if isConstraintViolation(err, referralCodeConstraint) {
+			return entities.ErrReferralCodeTaken
 		}
-		models = append(models, m)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entities.ErrUserNotFound
 	}
 
-	// мапим в сущности
-	leaders := make([]entities.UserLeader, len(models))
-	for i, m := range models {
-		leaders[i] = entities.UserLeader{
-			ID:       m.ID,
-			Username: m.Username,
-			Points:   m.Points,
-		}
+	return nil
+}
+
+// mapUserModelToEntity конвертирует модель базы в сущность
+func mapUserModelToEntity(m *UserModel) *entities.User {
+	return &entities.User{
+		ID:           m.ID,
+		Username:     m.Username,
+		Password:     m.PasswordHash,
+		Points:       m.Points,
+		ReferrerID:   m.ReferrerID,
+		ReferralCode: m.ReferralCode,
+		Role:         entities.Role(m.Role),
+		CreatedAt:    m.CreatedAt,
 	}
+}
 
-	return leaders, nil
+type UserLeaderBoardRow struct {
+	ID       uuid.UUID
+	Username string
+	Points   int
 }
 
-func (s *Storage) GetUserStatus(ctx context.Context, userID uuid.UUID) (*entities.UserStatus, error) {
+// GetUserStatus возвращает статус пользователя; тексты задач подбираются
+// по списку локалей в порядке предпочтения
+func (s *Storage) GetUserStatus(ctx context.Context, userID uuid.UUID, locales []string) (*entities.UserStatus, error) {
 	// 1. Получаем данные пользователя
 	const userQuery = `
-		SELECT id, username, points, referrer_id
+		SELECT id, username, points, referrer_id, referral_code
 		FROM users
 		WHERE id = $1
 	`
 	var status entities.UserStatus
-	err := s.db.QueryRow(ctx, userQuery, userID).Scan(
+	err := s.conn(ctx).QueryRow(ctx, userQuery, userID).Scan(
 		&status.ID,
 		&status.Username,
 		&status.Points,
 		&status.ReferrerID,
+		&status.ReferralCode,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -191,7 +238,7 @@ func (s *Storage) GetUserStatus(ctx context.Context, userID uuid.UUID) (*entitie
 	}
 
 	// 2. Получаем все выполненные задачи
-	tasks, err := s.GetCompletedTasks(ctx, userID)
+	tasks, err := s.GetCompletedTasks(ctx, userID, locales)
 	if err != nil {
 		return nil, err
 	}
@@ -200,11 +247,23 @@ func (s *Storage) GetUserStatus(ctx context.Context, userID uuid.UUID) (*entitie
 		status.CompletedTasks[i] = *t
 	}
 
+	// 3. Получаем прогресс по незавершённым задачам со счётчиком
+	status.TasksInProgress, err = s.fetchTasksInProgress(ctx, userID, locales)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Получаем выданные достижения
+	status.Badges, err = s.GetUserBadges(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &status, nil
 }
 
-func (s *Storage) GetCompletedTasks(ctx context.Context, userID uuid.UUID) ([]*entities.CompletedTask, error) {
-	tasks, err := s.fetchUserTasks(ctx, userID)
+func (s *Storage) GetCompletedTasks(ctx context.Context, userID uuid.UUID, locales []string) ([]*entities.CompletedTask, error) {
+	tasks, err := s.fetchUserTasks(ctx, userID, locales)
 	if err != nil || len(tasks) == 0 {
 		return tasks, err
 	}
@@ -218,15 +277,21 @@ func (s *Storage) GetCompletedTasks(ctx context.Context, userID uuid.UUID) ([]*e
 	return tasks, nil
 }
 
-func (s *Storage) fetchUserTasks(ctx context.Context, userID uuid.UUID) ([]*entities.CompletedTask, error) {
+func (s *Storage) fetchUserTasks(ctx context.Context, userID uuid.UUID, locales []string) ([]*entities.CompletedTask, error) {
 	const query = `
-		SELECT ut.id, t.code, t.description, t.reward_points, ut.completed_at
+		SELECT ut.id, t.code,
+		       COALESCE(tr.title, t.code),
+		       COALESCE(tr.description, t.description, ''),
+		       ut.points, ut.base_points, ut.multiplier, ut.multiplier_event_id, e.name,
+		       ut.completed_at
 		FROM user_tasks ut
 		JOIN tasks t ON ut.task_id = t.id
-		WHERE ut.user_id = $1
+		LEFT JOIN points_multiplier_events e ON e.id = ut.multiplier_event_id
+		LEFT JOIN LATERAL (` + taskTranslationQuery + `) tr ON TRUE
+		WHERE ut.user_id = $1 AND ut.revoked_at IS NULL
 		ORDER BY ut.completed_at
 	`
-	rows, err := s.db.Query(ctx, query, userID)
+	rows, err := s.conn(ctx).Query(ctx, query, userID, locales)
 	if err != nil {
 		return nil, err
 	}
@@ -234,16 +299,75 @@ func (s *Storage) fetchUserTasks(ctx context.Context, userID uuid.UUID) ([]*enti
 
 	var tasks []*entities.CompletedTask
 	for rows.Next() {
-		var t entities.CompletedTask
-		if err := rows.Scan(&t.ID, &t.Code, &t.Description, &t.Points, &t.CompletedAt); err != nil {
+		var (
+			t         entities.CompletedTask
+			eventID   *uuid.UUID
+			eventName *string
+		)
+		if err := rows.Scan(
+			&t.ID,
+			&t.Code,
+			&t.Title,
+			&t.Description,
+			&t.Points,
+			&t.BasePoints,
+			&t.Multiplier,
+			&eventID,
+			&eventName,
+			&t.CompletedAt,
+		); err != nil {
 			return nil, err
 		}
+
+		// буст показываем, если множитель применялся, даже если событие уже удалено
+		if t.Multiplier != 1 || eventID != nil {
+			t.Boost = &entities.PointsBoost{EventID: eventID}
+			if eventName != nil {
+				t.Boost.EventName = *eventName
+			}
+		}
+
 		tasks = append(tasks, &t)
 	}
 
 	return tasks, nil
 }
 
+func (s *Storage) fetchTasksInProgress(ctx context.Context, userID uuid.UUID, locales []string) ([]entities.TaskProgress, error) {
+	const query = `
+		SELECT t.id, t.code,
+		       COALESCE(tr.title, t.code),
+		       COALESCE(tr.description, t.description, ''),
+		       p.progress, t.target_count, p.updated_at
+		FROM user_task_progress p
+		JOIN tasks t ON p.task_id = t.id
+		LEFT JOIN LATERAL (` + taskTranslationQuery + `) tr ON TRUE
+		WHERE p.user_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM user_tasks ut
+		      WHERE ut.user_id = p.user_id AND ut.task_id = p.task_id
+		        AND (ut.revoked_at IS NULL OR NOT ut.allow_recomplete)
+		  )
+		ORDER BY p.updated_at DESC
+	`
+	rows, err := s.conn(ctx).Query(ctx, query, userID, locales)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := []entities.TaskProgress{}
+	for rows.Next() {
+		var p entities.TaskProgress
+		if err := rows.Scan(&p.TaskID, &p.Code, &p.Title, &p.Description, &p.Progress, &p.Target, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
 func (s *Storage) fetchTasksMetadata(ctx context.Context, tasks []*entities.CompletedTask) (map[uuid.UUID]map[string]string, error) {
 	userTaskIDs := make([]uuid.UUID, 0, len(tasks))
 	for _, t := range tasks {
@@ -255,7 +379,7 @@ func (s *Storage) fetchTasksMetadata(ctx context.Context, tasks []*entities.Comp
 		FROM user_task_metadata
 		WHERE user_task_id = ANY($1)
 	`
-	rows, err := s.db.Query(ctx, query, userTaskIDs)
+	rows, err := s.conn(ctx).Query(ctx, query, userTaskIDs)
 	if err != nil {
 		return nil, err
 	}
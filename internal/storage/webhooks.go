@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// webhookNonceCleanupBatch — сколько устаревших nonce удаляется за одну доставку
+const webhookNonceCleanupBatch = 100
+
+// SaveWebhookNonce запоминает nonce доставки партнёра.
+// Повторный nonce возвращает ErrWebhookReplay.
+// Попутно удаляет порцию nonce, полученных раньше expiredBefore: доставки с ними
+// уже не пройдут проверку подписи. Строки, занятые параллельной доставкой, пропускаются.
+func (s *Storage) SaveWebhookNonce(ctx context.Context, partner, nonce string, expiredBefore time.Time) error {
+	const cleanupQuery = `
+		DELETE FROM webhook_nonces
+		WHERE (partner, nonce) IN (
+			SELECT partner, nonce
+			FROM webhook_nonces
+			WHERE received_at < $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`
+
+	if _, err := s.conn(ctx).Exec(ctx, cleanupQuery, expiredBefore, webhookNonceCleanupBatch); err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO webhook_nonces (partner, nonce, received_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (partner, nonce) DO NOTHING
+	`
+
+	tag, err := s.conn(ctx).Exec(ctx, query, partner, nonce, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entities.ErrWebhookReplay
+	}
+
+	return nil
+}
+
+// SaveUserTaskPayload сохраняет тело доставки партнёра, через которую засчитано выполнение
+func (s *Storage) SaveUserTaskPayload(ctx context.Context, userTaskID uuid.UUID, payload []byte) error {
+	const query = `
+		UPDATE user_tasks
+		SET payload = $2::jsonb
+		WHERE id = $1
+	`
+
+	_, err := s.conn(ctx).Exec(ctx, query, userTaskID, string(payload))
+	return err
+}
+
+// GetPartnerUserID возвращает нашего пользователя по идентификатору в системе партнёра
+func (s *Storage) GetPartnerUserID(ctx context.Context, partner, externalUserID string) (uuid.UUID, error) {
+	const query = `
+		SELECT user_id
+		FROM partner_user_links
+		WHERE partner = $1 AND external_user_id = $2
+	`
+
+	var userID uuid.UUID
+	err := s.conn(ctx).QueryRow(ctx, query, partner, externalUserID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, entities.ErrPartnerUserNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+// UpsertPartnerUserLink связывает пользователя партнёра с нашим пользователем.
+// Один наш пользователь может быть связан только с одним аккаунтом партнёра.
+func (s *Storage) UpsertPartnerUserLink(ctx context.Context, link entities.PartnerUserLink) (*entities.PartnerUserLink, error) {
+	const query = `
+		INSERT INTO partner_user_links (partner, external_user_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (partner, external_user_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id
+		RETURNING partner, external_user_id, user_id, created_at
+	`
+
+	var saved entities.PartnerUserLink
+	err := s.conn(ctx).QueryRow(ctx, query, link.Partner, link.ExternalUserID, link.UserID, time.Now()).Scan(
+		&saved.Partner,
+		&saved.ExternalUserID,
+		&saved.UserID,
+		&saved.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, entities.ErrPartnerUserAlreadyLinked
+		}
+		return nil, err
+	}
+
+	return &saved, nil
+}
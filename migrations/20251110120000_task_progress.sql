@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Задачи со счётчиком: target_count > 1 означает, что задача выполняется накоплением прогресса
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS target_count   INT NOT NULL DEFAULT 1 CHECK (target_count > 0), -- сколько шагов нужно для выполнения
+    ADD COLUMN IF NOT EXISTS progress_event VARCHAR(100);                                     -- внутреннее событие, двигающее прогресс (NULL — прогресс приходит через API)
+
+-- Гонка проверки и вставки в CompleteTask могла записать одно выполнение дважды.
+-- Оставляем самое раннее выполнение и переносим на него метаданные дублей,
+-- иначе уникальный индекс ниже не создастся.
+INSERT INTO user_task_metadata (user_task_id, key, value)
+SELECT d.keep_id, m.key, m.value
+FROM (
+    SELECT id, FIRST_VALUE(id) OVER (PARTITION BY user_id, task_id ORDER BY completed_at, id) AS keep_id
+    FROM user_tasks
+) d
+JOIN user_task_metadata m ON m.user_task_id = d.id
+WHERE d.id <> d.keep_id
+ON CONFLICT (user_task_id, key, value) DO NOTHING;
+
+-- Каждый дубль начислил награду задачи ещё раз: списываем эти баллы,
+-- чтобы баланс совпадал с оставшимися выполнениями
+UPDATE users u
+SET points = u.points - d.extra_points
+FROM (
+    SELECT ut.user_id, SUM(t.reward_points) AS extra_points
+    FROM (
+        SELECT id, user_id, task_id,
+               ROW_NUMBER() OVER (PARTITION BY user_id, task_id ORDER BY completed_at, id) AS rn
+        FROM user_tasks
+    ) ut
+    JOIN tasks t ON t.id = ut.task_id
+    WHERE ut.rn > 1
+    GROUP BY ut.user_id
+) d
+WHERE u.id = d.user_id;
+
+DELETE FROM user_tasks u
+USING (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, task_id ORDER BY completed_at, id) AS rn
+    FROM user_tasks
+) d
+WHERE u.id = d.id AND d.rn > 1;
+
+-- Одна задача выполняется пользователем не более одного раза
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_tasks_user_task ON user_tasks(user_id, task_id);
+
+-- Прогресс пользователей по задачам со счётчиком
+CREATE TABLE IF NOT EXISTS user_task_progress (
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- пользователь
+    task_id         UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE, -- задача со счётчиком
+    progress        INT NOT NULL DEFAULT 0 CHECK (progress >= 0),         -- текущее значение счётчика
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW(),                     -- время последнего изменения
+    PRIMARY KEY (user_id, task_id)
+);
+
+-- Задача со счётчиком, которую двигают новые рефералы
+INSERT INTO tasks (id, code, description, reward_points, target_count, progress_event, created_at) VALUES
+    (uuid_generate_v4(), 'invite_5_friends', 'Приглашает 5 друзей и получает награду', 250, 5, 'referral_joined', NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tasks WHERE code = 'invite_5_friends';
+DROP TABLE IF EXISTS user_task_progress;
+DROP INDEX IF EXISTS uq_user_tasks_user_task;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS progress_event,
+    DROP COLUMN IF EXISTS target_count;
+-- +goose StatementEnd
@@ -0,0 +1,112 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Роль пользователя: user или admin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
+
+-- Категории задач
+CREATE TABLE IF NOT EXISTS task_categories (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(), -- уникальный идентификатор категории
+    code            VARCHAR(100) NOT NULL UNIQUE,               -- системное имя категории (например: "social")
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()            -- дата создания категории
+);
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES task_categories(id) ON DELETE SET NULL; -- категория задачи (может быть NULL)
+
+CREATE INDEX IF NOT EXISTS idx_tasks_category_id ON tasks(category_id);
+
+-- Теги задач
+CREATE TABLE IF NOT EXISTS task_tags (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(), -- уникальный идентификатор тега
+    code            VARCHAR(100) NOT NULL UNIQUE,               -- системное имя тега (например: "telegram")
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()            -- дата создания тега
+);
+
+-- Связь задач и тегов
+CREATE TABLE IF NOT EXISTS task_tag_links (
+    task_id         UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,     -- задача
+    tag_id          UUID NOT NULL REFERENCES task_tags(id) ON DELETE CASCADE, -- тег
+    PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tag_links_tag_id ON task_tag_links(tag_id);
+
+-- Переводы заголовков и описаний задач
+CREATE TABLE IF NOT EXISTS task_translations (
+    task_id         UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE, -- задача
+    locale          VARCHAR(35) NOT NULL,                                 -- языковой тег в нижнем регистре (например: "ru", "en-us")
+    title           VARCHAR(255) NOT NULL,                                -- заголовок задачи
+    description     TEXT,                                                 -- описание задачи
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW(),                     -- дата последнего изменения
+    PRIMARY KEY (task_id, locale)
+);
+
+-- Стандартные категории и теги
+INSERT INTO task_categories (code) VALUES
+    ('social'),
+    ('referral')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO task_tags (code) VALUES
+    ('telegram'),
+    ('twitter'),
+    ('referral')
+ON CONFLICT (code) DO NOTHING;
+
+UPDATE tasks SET category_id = (SELECT id FROM task_categories WHERE code = 'social')
+WHERE code IN ('subscribe_telegram', 'follow_twitter');
+
+UPDATE tasks SET category_id = (SELECT id FROM task_categories WHERE code = 'referral')
+WHERE code IN ('enter_referral_code', 'invite_5_friends');
+
+INSERT INTO task_tag_links (task_id, tag_id)
+SELECT t.id, tg.id
+FROM tasks t
+JOIN task_tags tg ON (t.code, tg.code) IN (
+    ('subscribe_telegram', 'telegram'),
+    ('follow_twitter', 'twitter'),
+    ('enter_referral_code', 'referral'),
+    ('invite_5_friends', 'referral')
+)
+ON CONFLICT DO NOTHING;
+
+-- Переносим существующие русские описания в переводы
+INSERT INTO task_translations (task_id, locale, title, description)
+SELECT t.id, 'ru', t.code, t.description
+FROM tasks t
+ON CONFLICT (task_id, locale) DO NOTHING;
+
+UPDATE task_translations tt SET title = v.title
+FROM tasks t, (VALUES
+    ('enter_referral_code', 'Введите реферальный код'),
+    ('subscribe_telegram', 'Подпишитесь на Telegram-канал'),
+    ('follow_twitter', 'Подпишитесь на Twitter'),
+    ('invite_5_friends', 'Пригласите 5 друзей')
+) AS v(code, title)
+WHERE tt.task_id = t.id AND t.code = v.code AND tt.locale = 'ru';
+
+INSERT INTO task_translations (task_id, locale, title, description)
+SELECT t.id, 'en', v.title, v.description
+FROM tasks t
+JOIN (VALUES
+    ('enter_referral_code', 'Enter a referral code', 'Enter a referral code and get a reward'),
+    ('subscribe_telegram', 'Subscribe to the Telegram channel', 'Subscribe to the Telegram channel and get a reward'),
+    ('follow_twitter', 'Follow us on Twitter', 'Follow us on Twitter and get a reward'),
+    ('invite_5_friends', 'Invite 5 friends', 'Invite 5 friends and get a reward')
+) AS v(code, title, description) ON t.code = v.code
+ON CONFLICT (task_id, locale) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS task_translations;
+DROP TABLE IF EXISTS task_tag_links;
+DROP TABLE IF EXISTS task_tags;
+DROP INDEX IF EXISTS idx_tasks_category_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS task_categories;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
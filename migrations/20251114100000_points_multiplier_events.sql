@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- События-множители наград ("двойные баллы на выходных")
+CREATE TABLE IF NOT EXISTS points_multiplier_events (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),    -- уникальный идентификатор события
+    name            VARCHAR(255) NOT NULL,                         -- название события
+    multiplier      NUMERIC(6, 2) NOT NULL CHECK (multiplier > 0), -- множитель награды
+    starts_at       TIMESTAMP NOT NULL,                            -- начало действия (включительно)
+    ends_at         TIMESTAMP NOT NULL,                            -- конец действия (не включительно)
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),              -- дата создания события
+    CONSTRAINT chk_points_multiplier_events_window CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_multiplier_events_window ON points_multiplier_events(starts_at, ends_at);
+
+-- Задачи, на которые действует событие (пусто вместе с категориями — действует на все задачи)
+CREATE TABLE IF NOT EXISTS points_multiplier_event_tasks (
+    event_id        UUID NOT NULL REFERENCES points_multiplier_events(id) ON DELETE CASCADE,
+    task_id         UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, task_id)
+);
+
+-- Категории, на которые действует событие
+CREATE TABLE IF NOT EXISTS points_multiplier_event_categories (
+    event_id        UUID NOT NULL REFERENCES points_multiplier_events(id) ON DELETE CASCADE,
+    category_id     UUID NOT NULL REFERENCES task_categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, category_id)
+);
+
+-- Начисление фиксируется на выполнении: базовая награда, множитель и событие
+ALTER TABLE user_tasks
+    ADD COLUMN IF NOT EXISTS base_points         INT,                                                           -- награда задачи без множителя
+    ADD COLUMN IF NOT EXISTS multiplier          NUMERIC(6, 2) NOT NULL DEFAULT 1,                              -- применённый множитель
+    ADD COLUMN IF NOT EXISTS multiplier_event_id UUID REFERENCES points_multiplier_events(id) ON DELETE SET NULL, -- событие, давшее множитель
+    ADD COLUMN IF NOT EXISTS points              INT;                                                           -- итоговое начисление
+
+UPDATE user_tasks ut
+SET base_points = t.reward_points,
+    points      = t.reward_points
+FROM tasks t
+WHERE t.id = ut.task_id AND ut.points IS NULL;
+
+ALTER TABLE user_tasks
+    ALTER COLUMN base_points SET NOT NULL,
+    ALTER COLUMN points SET NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_tasks
+    DROP COLUMN IF EXISTS points,
+    DROP COLUMN IF EXISTS multiplier_event_id,
+    DROP COLUMN IF EXISTS multiplier,
+    DROP COLUMN IF EXISTS base_points;
+DROP TABLE IF EXISTS points_multiplier_event_categories;
+DROP TABLE IF EXISTS points_multiplier_event_tasks;
+DROP INDEX IF EXISTS idx_points_multiplier_events_window;
+DROP TABLE IF EXISTS points_multiplier_events;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Отзыв выполнения: запись остаётся в таблице как надгробие для аудита
+ALTER TABLE user_tasks
+    ADD COLUMN IF NOT EXISTS revoked_at       TIMESTAMP,                                          -- дата отзыва (NULL — выполнение действует)
+    ADD COLUMN IF NOT EXISTS revoked_by       UUID REFERENCES users(id) ON DELETE SET NULL,       -- администратор, отозвавший выполнение
+    ADD COLUMN IF NOT EXISTS revoke_reason    TEXT,                                               -- причина отзыва
+    ADD COLUMN IF NOT EXISTS allow_recomplete BOOLEAN NOT NULL DEFAULT FALSE;                     -- можно ли выполнить задачу повторно
+
+-- Уникальность действует только для неотозванных выполнений
+DROP INDEX IF EXISTS uq_user_tasks_user_task;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_tasks_user_task_active ON user_tasks(user_id, task_id) WHERE revoked_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_tasks WHERE revoked_at IS NOT NULL;
+DROP INDEX IF EXISTS uq_user_tasks_user_task_active;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_tasks_user_task ON user_tasks(user_id, task_id);
+ALTER TABLE user_tasks
+    DROP COLUMN IF EXISTS allow_recomplete,
+    DROP COLUMN IF EXISTS revoke_reason,
+    DROP COLUMN IF EXISTS revoked_by,
+    DROP COLUMN IF EXISTS revoked_at;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Принятые nonce доставок партнёров (защита от повторов)
+CREATE TABLE IF NOT EXISTS webhook_nonces (
+    partner         VARCHAR(100) NOT NULL,            -- идентификатор партнёра
+    nonce           VARCHAR(255) NOT NULL,            -- уникальный идентификатор доставки
+    received_at     TIMESTAMP NOT NULL DEFAULT NOW(), -- дата получения доставки
+    PRIMARY KEY (partner, nonce)
+);
+
+-- Для удаления nonce, подписи которых уже отклоняются по времени
+CREATE INDEX IF NOT EXISTS idx_webhook_nonces_received_at ON webhook_nonces(received_at);
+
+-- Связь пользователей партнёра с нашими пользователями
+CREATE TABLE IF NOT EXISTS partner_user_links (
+    partner          VARCHAR(100) NOT NULL,                                  -- идентификатор партнёра
+    external_user_id VARCHAR(255) NOT NULL,                                  -- идентификатор пользователя у партнёра
+    user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,   -- наш пользователь
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),                       -- дата создания связи
+    PRIMARY KEY (partner, external_user_id),
+    CONSTRAINT uq_partner_user_links_partner_user UNIQUE (partner, user_id)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS partner_user_links;
+DROP TABLE IF EXISTS webhook_nonces;
+-- +goose StatementEnd
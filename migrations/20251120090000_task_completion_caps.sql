@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Глобальный лимит выполнений задачи ("только первые 1000 пользователей")
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS completion_cap    INT CHECK (completion_cap > 0),                        -- лимит выполнений, NULL — без лимита
+    ADD COLUMN IF NOT EXISTS completions_count INT NOT NULL DEFAULT 0 CHECK (completions_count >= 0); -- число действующих выполнений
+
+UPDATE tasks t
+SET completions_count = (
+    SELECT COUNT(*) FROM user_tasks ut
+    WHERE ut.task_id = t.id AND ut.revoked_at IS NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS completions_count,
+    DROP COLUMN IF EXISTS completion_cap;
+-- +goose StatementEnd
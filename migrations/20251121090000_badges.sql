@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Достижения с декларативными правилами выдачи
+CREATE TABLE IF NOT EXISTS badges (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),                       -- уникальный идентификатор достижения
+    code            VARCHAR(100) NOT NULL UNIQUE,                                     -- системное имя достижения (например: "first_task")
+    title           VARCHAR(255) NOT NULL,                                            -- название достижения
+    description     TEXT,                                                             -- описание достижения
+    event           VARCHAR(64) NOT NULL
+        CHECK (event IN ('task_completed', 'referrer_set', 'points_changed')),        -- событие, при котором проверяется правило
+    metric          VARCHAR(64) NOT NULL
+        CHECK (metric IN ('completed_tasks', 'referrals', 'points', 'leaderboard_rank')), -- показатель пользователя
+    threshold       INT NOT NULL CHECK (threshold > 0),                               -- порог (для leaderboard_rank — худшее допустимое место)
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()                                  -- дата создания достижения
+);
+
+CREATE INDEX IF NOT EXISTS idx_badges_event ON badges(event);
+
+-- Выданные пользователям достижения
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,  -- пользователь
+    badge_id        UUID NOT NULL REFERENCES badges(id) ON DELETE CASCADE, -- достижение
+    awarded_at      TIMESTAMP NOT NULL DEFAULT NOW(),                      -- дата выдачи
+    PRIMARY KEY (user_id, badge_id)
+);
+
+-- Стандартные достижения
+INSERT INTO badges (code, title, description, event, metric, threshold) VALUES
+    ('first_task', 'Первое задание', 'Выполните первое задание', 'task_completed', 'completed_tasks', 1),
+    ('top_100', 'Топ-100', 'Войдите в сотню лучших в общем рейтинге', 'points_changed', 'leaderboard_rank', 100),
+    ('referred_10', 'Амбассадор', 'Пригласите 10 пользователей', 'referrer_set', 'referrals', 10)
+ON CONFLICT (code) DO NOTHING;
+
+-- Выдаём достижения за уже накопленные показатели
+INSERT INTO user_badges (user_id, badge_id)
+SELECT c.user_id, b.id
+FROM badges b
+JOIN (
+    SELECT user_id, COUNT(*) AS value
+    FROM user_tasks
+    WHERE revoked_at IS NULL
+    GROUP BY user_id
+) c ON c.value >= b.threshold
+WHERE b.metric = 'completed_tasks'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_badges (user_id, badge_id)
+SELECT r.user_id, b.id
+FROM badges b
+JOIN (
+    SELECT referrer_id AS user_id, COUNT(*) AS value
+    FROM users
+    WHERE referrer_id IS NOT NULL
+    GROUP BY referrer_id
+) r ON r.value >= b.threshold
+WHERE b.metric = 'referrals'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_badges;
+DROP INDEX IF EXISTS idx_badges_event;
+DROP TABLE IF EXISTS badges;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Журнал баллов: одна запись на каждое начисление или списание.
+-- Записи только добавляются; users.points — кеш суммы записей пользователя.
+CREATE TABLE IF NOT EXISTS points_ledger (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),          -- уникальный идентификатор записи
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- пользователь
+    amount          INT NOT NULL CHECK (amount <> 0),                     -- изменение баланса: > 0 начисление, < 0 списание
+    reason_type     VARCHAR(64) NOT NULL,                                 -- причина (task_completed, task_revoked, opening_balance, ...)
+    reference_id    UUID,                                                 -- объект, вызвавший изменение (например: user_tasks.id)
+    balance_after   INT NOT NULL,                                         -- баланс пользователя после изменения
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()                      -- дата изменения
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_ledger_user_created ON points_ledger(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_points_ledger_reference ON points_ledger(reference_id) WHERE reference_id IS NOT NULL;
+
+-- Переносим накопленные балансы одной входящей записью
+INSERT INTO points_ledger (user_id, amount, reason_type, balance_after, created_at)
+SELECT u.id, u.points, 'opening_balance', u.points, NOW()
+FROM users u
+WHERE u.points <> 0
+  AND NOT EXISTS (SELECT 1 FROM points_ledger l WHERE l.user_id = u.id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_points_ledger_reference;
+DROP INDEX IF EXISTS idx_points_ledger_user_created;
+DROP TABLE IF EXISTS points_ledger;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Награды магазина, покупаются за баллы
+CREATE TABLE IF NOT EXISTS rewards (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),            -- уникальный идентификатор награды
+    code            VARCHAR(100) NOT NULL UNIQUE,                          -- системное имя награды (например: "tshirt")
+    title           VARCHAR(255) NOT NULL,                                 -- название награды
+    description     TEXT,                                                  -- описание награды
+    price           INT NOT NULL CHECK (price > 0),                        -- цена в баллах
+    stock           INT CHECK (stock >= 0),                                -- остаток, NULL — без ограничения
+    per_user_limit  INT CHECK (per_user_limit > 0),                        -- лимит на пользователя, NULL — без ограничения
+    active          BOOLEAN NOT NULL DEFAULT TRUE,                         -- доступна ли награда для покупки
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()                       -- дата создания награды
+);
+
+-- Заказы наград
+CREATE TABLE IF NOT EXISTS reward_redemptions (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),                    -- уникальный идентификатор заказа
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,          -- покупатель
+    reward_id       UUID NOT NULL REFERENCES rewards(id) ON DELETE RESTRICT,       -- награда
+    price           INT NOT NULL,                                                  -- списанная сумма на момент заказа
+    status          VARCHAR(32) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'fulfilled', 'cancelled')),                   -- статус заказа
+    processed_by    UUID REFERENCES users(id) ON DELETE SET NULL,                  -- администратор, обработавший заказ
+    note            TEXT,                                                          -- комментарий администратора
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),                              -- дата заказа
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW()                               -- дата последнего изменения статуса
+);
+
+CREATE INDEX IF NOT EXISTS idx_reward_redemptions_user_reward ON reward_redemptions(user_id, reward_id);
+CREATE INDEX IF NOT EXISTS idx_reward_redemptions_status_created ON reward_redemptions(status, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reward_redemptions_status_created;
+DROP INDEX IF EXISTS idx_reward_redemptions_user_reward;
+DROP TABLE IF EXISTS reward_redemptions;
+DROP TABLE IF EXISTS rewards;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Переводы баллов между пользователями
+CREATE TABLE IF NOT EXISTS points_transfers (
+    id              UUID PRIMARY KEY,                                       -- уникальный идентификатор перевода (reference в points_ledger)
+    sender_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,  -- отправитель
+    recipient_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,  -- получатель
+    amount          INT NOT NULL CHECK (amount > 0),                       -- сумма перевода
+    note            VARCHAR(255),                                          -- комментарий отправителя
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),                      -- дата перевода
+    CONSTRAINT chk_points_transfers_parties CHECK (sender_id <> recipient_id)
+);
+
+-- Суточный лимит считается по исходящим переводам отправителя
+CREATE INDEX IF NOT EXISTS idx_points_transfers_sender_created ON points_transfers(sender_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_points_transfers_sender_created;
+DROP TABLE IF EXISTS points_transfers;
+-- +goose StatementEnd
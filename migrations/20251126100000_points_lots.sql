@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Партии начисленных баллов. Списания расходуют партии по FIFO,
+-- непотраченный остаток сгорает в expires_at. Сумма остатков — положительная часть users.points.
+CREATE TABLE IF NOT EXISTS points_lots (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),                 -- уникальный идентификатор партии
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,        -- владелец баллов
+    ledger_entry_id UUID REFERENCES points_ledger(id) ON DELETE SET NULL,        -- начисление, создавшее партию
+    source          VARCHAR(64) NOT NULL,                                        -- причина начисления (task_completed, transfer_in, ...)
+    amount          INT NOT NULL CHECK (amount > 0),                             -- начислено в партии
+    remaining       INT NOT NULL CHECK (remaining >= 0 AND remaining <= amount), -- ещё не потрачено и не сгорело
+    expires_at      TIMESTAMP,                                                   -- срок сгорания, NULL — не сгорает
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()                             -- дата начисления
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_lots_user_open ON points_lots(user_id, created_at, id) WHERE remaining > 0;
+CREATE INDEX IF NOT EXISTS idx_points_lots_expires ON points_lots(expires_at) WHERE remaining > 0 AND expires_at IS NOT NULL;
+
+-- Накопленные балансы переносим бессрочной партией: они начислены до появления политики сгорания
+INSERT INTO points_lots (user_id, source, amount, remaining, created_at)
+SELECT u.id, 'opening_balance', u.points, u.points, NOW()
+FROM users u
+WHERE u.points > 0
+  AND NOT EXISTS (SELECT 1 FROM points_lots l WHERE l.user_id = u.id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_points_lots_expires;
+DROP INDEX IF EXISTS idx_points_lots_user_open;
+DROP TABLE IF EXISTS points_lots;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Запросы ручных корректировок: ключ идемпотентности и отпечаток содержимого
+CREATE TABLE IF NOT EXISTS points_adjustment_batches (
+    idempotency_key VARCHAR(255) PRIMARY KEY,        -- ключ из заголовка Idempotency-Key
+    request_hash    VARCHAR(64) NOT NULL,            -- sha256 содержимого запроса
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW() -- дата применения
+);
+
+-- Журнал аудита ручных корректировок баланса
+CREATE TABLE IF NOT EXISTS points_adjustments (
+    id              UUID PRIMARY KEY,                                                                 -- идентификатор корректировки (reference в points_ledger)
+    batch_key       VARCHAR(255) NOT NULL REFERENCES points_adjustment_batches(idempotency_key),      -- запрос, в котором пришла корректировка
+    position        INT NOT NULL,                                                                     -- порядковый номер в запросе
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,                             -- пользователь
+    admin_id        UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,                            -- администратор, выполнивший корректировку
+    amount          INT NOT NULL CHECK (amount <> 0),                                                 -- изменение баланса
+    reason_code     VARCHAR(32) NOT NULL
+        CHECK (reason_code IN ('compensation', 'correction', 'goodwill', 'fraud')),                   -- код причины
+    note            TEXT NOT NULL,                                                                    -- комментарий администратора
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),                                                 -- дата корректировки
+    UNIQUE (batch_key, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_adjustments_user_created ON points_adjustments(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_points_adjustments_admin_created ON points_adjustments(admin_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_points_adjustments_admin_created;
+DROP INDEX IF EXISTS idx_points_adjustments_user_created;
+DROP TABLE IF EXISTS points_adjustments;
+DROP TABLE IF EXISTS points_adjustment_batches;
+-- +goose StatementEnd
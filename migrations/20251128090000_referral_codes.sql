@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Код приглашения пользователя. Хранится в верхнем регистре, сравнение без учёта регистра
+-- обеспечивается нормализацией на входе.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS referral_code VARCHAR(16); -- код приглашения (например: "K7M2QX9A")
+
+-- Индекс создаётся до заполнения: NULL не конфликтуют, а заполнение ищет по нему занятые коды
+CREATE UNIQUE INDEX IF NOT EXISTS uq_users_referral_code ON users(referral_code);
+
+-- Существующие пользователи получают коды того же вида, что и новые (internal/pkg/refcode):
+-- 8 символов алфавита без 0/O и 1/I/L. Занятый код генерируется заново.
+DO $$
+DECLARE
+    alphabet  CONSTANT TEXT := '23456789ABCDEFGHJKMNPQRSTUVWXYZ';
+    target_id UUID;
+    code      TEXT;
+BEGIN
+    FOR target_id IN SELECT id FROM users WHERE referral_code IS NULL LOOP
+        LOOP
+            code := '';
+            FOR i IN 1..8 LOOP
+                code := code || substr(alphabet, 1 + floor(random() * length(alphabet))::int, 1);
+            END LOOP;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM users WHERE referral_code = code);
+        END LOOP;
+
+        UPDATE users SET referral_code = code WHERE id = target_id;
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE users
+    ALTER COLUMN referral_code SET NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_users_referral_code;
+ALTER TABLE users DROP COLUMN IF EXISTS referral_code;
+-- +goose StatementEnd
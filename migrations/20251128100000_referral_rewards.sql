@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Бонусы за приглашения. Размеры фиксируются при указании реферера
+CREATE TABLE IF NOT EXISTS referral_rewards (
+    referee_id           UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,  -- приглашённый
+    referrer_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,     -- реферер
+    referee_bonus        INT NOT NULL CHECK (referee_bonus >= 0),                  -- бонус приглашённому
+    referrer_bonus       INT NOT NULL CHECK (referrer_bonus >= 0),                 -- бонус рефереру
+    referrer_after_tasks INT NOT NULL DEFAULT 0 CHECK (referrer_after_tasks >= 0), -- сколько заданий должен выполнить приглашённый до бонуса рефереру
+    referrer_paid_at     TIMESTAMP,                                                -- дата начисления бонуса рефереру, NULL — ещё не начислен
+    created_at           TIMESTAMP NOT NULL DEFAULT NOW()                          -- дата указания реферера
+);
+
+CREATE INDEX IF NOT EXISTS idx_referral_rewards_referrer ON referral_rewards(referrer_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_referral_rewards_referrer;
+DROP TABLE IF EXISTS referral_rewards;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Комиссии рефереров с начислений пользователей их сети
+CREATE TABLE IF NOT EXISTS referral_commissions (
+    id              UUID PRIMARY KEY,                                           -- идентификатор комиссии (reference в points_ledger)
+    beneficiary_id  UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,      -- реферер, получивший комиссию
+    source_user_id  UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,      -- пользователь, чьё начисление стало источником
+    level           INT NOT NULL CHECK (level > 0),                            -- уровень реферера относительно источника
+    source_entry_id UUID NOT NULL REFERENCES points_ledger(id) ON DELETE CASCADE, -- исходное начисление в журнале
+    source_reason   VARCHAR(64) NOT NULL,                                      -- причина исходного начисления
+    source_amount   INT NOT NULL,                                              -- сумма исходного начисления
+    rate_bps        INT NOT NULL CHECK (rate_bps > 0),                         -- ставка в сотых долях процента
+    amount          INT NOT NULL CHECK (amount > 0),                           -- сумма комиссии
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),                          -- дата начисления
+    UNIQUE (source_entry_id, beneficiary_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_referral_commissions_beneficiary_source ON referral_commissions(beneficiary_id, source_user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_referral_commissions_beneficiary_source;
+DROP TABLE IF EXISTS referral_commissions;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Пользователь не может быть собственным реферером.
+-- NOT VALID: ограничение действует на новые записи, исторические строки не перепроверяются
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_referrer_not_self CHECK (referrer_id <> id) NOT VALID;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_referrer_not_self;
+-- +goose StatementEnd
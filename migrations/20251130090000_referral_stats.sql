@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Дата указания реферера; для старых записей — дата бонуса за приглашение или регистрации
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS referred_at TIMESTAMP; -- дата указания реферера, NULL — реферер не указан
+
+UPDATE users u
+SET referred_at = COALESCE(
+    (SELECT rr.created_at FROM referral_rewards rr WHERE rr.referee_id = u.id),
+    u.created_at
+)
+WHERE u.referrer_id IS NOT NULL AND u.referred_at IS NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_referred_at CHECK (referrer_id IS NULL OR referred_at IS NOT NULL);
+
+-- Приглашённые реферера, новые первыми
+CREATE INDEX IF NOT EXISTS idx_users_referrer_referred ON users(referrer_id, referred_at DESC, id DESC) WHERE referrer_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_referrer_referred;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_referred_at;
+ALTER TABLE users DROP COLUMN IF EXISTS referred_at;
+-- +goose StatementEnd
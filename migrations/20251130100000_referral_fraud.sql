@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Данные регистрации для поиска ферм аккаунтов
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS signup_ip         VARCHAR(64),  -- IP-адрес регистрации, NULL — неизвестен
+    ADD COLUMN IF NOT EXISTS signup_user_agent VARCHAR(512); -- User-Agent регистрации, NULL — неизвестен
+
+-- Бонусы подозрительных приглашений задерживаются до проверки
+ALTER TABLE referral_rewards
+    ADD COLUMN IF NOT EXISTS held            BOOLEAN NOT NULL DEFAULT FALSE, -- бонусы задержаны до решения администратора
+    ADD COLUMN IF NOT EXISTS referee_paid_at TIMESTAMP;                      -- дата начисления бонуса приглашённому, NULL — ещё не начислен
+
+-- До появления проверки бонус приглашённому начислялся сразу
+UPDATE referral_rewards SET referee_paid_at = created_at WHERE referee_bonus > 0 AND referee_paid_at IS NULL;
+
+-- Очередь проверки подозрительных приглашений
+CREATE TABLE IF NOT EXISTS referral_reviews (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),                 -- идентификатор проверки
+    referee_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,       -- приглашённый
+    referrer_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,       -- реферер
+    trigger         VARCHAR(32) NOT NULL CHECK (trigger IN ('referrer_set', 'task_completed')), -- момент, в который сработала проверка
+    score           INT NOT NULL,                                               -- оценка подозрительности
+    signals         TEXT[] NOT NULL DEFAULT '{}',                               -- сработавшие признаки
+    status          VARCHAR(32) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by     UUID REFERENCES users(id) ON DELETE SET NULL,               -- администратор, принявший решение
+    note            TEXT,                                                       -- комментарий администратора
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),                           -- дата постановки в очередь
+    reviewed_at     TIMESTAMP                                                   -- дата решения
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_referral_reviews_referee_pending ON referral_reviews(referee_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_referral_reviews_status_created ON referral_reviews(status, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_referral_reviews_status_created;
+DROP INDEX IF EXISTS uq_referral_reviews_referee_pending;
+DROP TABLE IF EXISTS referral_reviews;
+ALTER TABLE referral_rewards
+    DROP COLUMN IF EXISTS referee_paid_at,
+    DROP COLUMN IF EXISTS held;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS signup_user_agent,
+    DROP COLUMN IF EXISTS signup_ip;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Маркетинговая атрибуция регистрации
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS utm_source   VARCHAR(255), -- источник перехода, NULL — не передан
+    ADD COLUMN IF NOT EXISTS utm_campaign VARCHAR(255); -- кампания, NULL — не передана
+
+CREATE INDEX IF NOT EXISTS idx_users_utm ON users(utm_source, utm_campaign, created_at) WHERE utm_source IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_utm;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_source;
+-- +goose StatementEnd
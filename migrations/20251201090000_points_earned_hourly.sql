@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Заработанные баллы по часам для рейтингов за период.
+-- Часовые корзины позволяют считать сутки, неделю и месяц в любой зоне с целым смещением
+-- без сканирования журнала.
+CREATE TABLE IF NOT EXISTS points_earned_hourly (
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- пользователь
+    bucket_start    TIMESTAMP NOT NULL,                                   -- начало часа
+    points          INT NOT NULL DEFAULT 0,                               -- заработано за час (отзывы уменьшают)
+    PRIMARY KEY (user_id, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_earned_hourly_bucket ON points_earned_hourly(bucket_start) INCLUDE (user_id, points);
+
+INSERT INTO points_earned_hourly (user_id, bucket_start, points)
+SELECT l.user_id, date_trunc('hour', l.created_at), SUM(l.amount)
+FROM points_ledger l
+WHERE l.reason_type IN (
+    'task_completed', 'task_revoked', 'referee_bonus', 'referrer_bonus', 'referral_commission', 'admin_adjustment'
+)
+GROUP BY l.user_id, date_trunc('hour', l.created_at)
+ON CONFLICT (user_id, bucket_start) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_points_earned_hourly_bucket;
+DROP TABLE IF EXISTS points_earned_hourly;
+-- +goose StatementEnd
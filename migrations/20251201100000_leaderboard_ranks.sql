@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Рейтинг по балансу упорядочен по баллам, при равенстве — по id пользователя
+DROP INDEX IF EXISTS idx_users_points_desc;
+CREATE INDEX IF NOT EXISTS idx_users_points_id ON users(points DESC, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_points_id;
+CREATE INDEX IF NOT EXISTS idx_users_points_desc ON users(points DESC);
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Комиссии, списанные с реферера при отзыве исходного начисления
+ALTER TABLE referral_commissions
+    ADD COLUMN IF NOT EXISTS reversed_at TIMESTAMP; -- время списания, NULL — комиссия действует
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE referral_commissions
+    DROP COLUMN IF EXISTS reversed_at;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Тело доставки партнёра хранится отдельно от метаданных: значения метаданных входят
+-- в уникальный btree-индекс и не могут быть длиннее нескольких килобайт
+ALTER TABLE user_tasks
+    ADD COLUMN IF NOT EXISTS payload JSONB; -- тело вебхука, через который засчитано выполнение
+
+UPDATE user_tasks ut
+SET payload = m.value::jsonb
+FROM user_task_metadata m
+WHERE m.user_task_id = ut.id AND m.key = 'webhook_payload';
+
+DELETE FROM user_task_metadata WHERE key = 'webhook_payload';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO user_task_metadata (user_task_id, key, value)
+SELECT id, 'webhook_payload', payload::text
+FROM user_tasks
+WHERE payload IS NOT NULL
+ON CONFLICT (user_task_id, key, value) DO NOTHING;
+
+ALTER TABLE user_tasks
+    DROP COLUMN IF EXISTS payload;
+-- +goose StatementEnd
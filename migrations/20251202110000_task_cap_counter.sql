@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Счётчик выполнений ведётся только у задач с лимитом, чтобы выполнения задач без лимита
+-- не блокировали строку задачи. При установке лимита счётчик пересчитывается
+-- по действующим выполнениям.
+CREATE OR REPLACE FUNCTION tasks_recount_completions() RETURNS trigger AS $$
+BEGIN
+    NEW.completions_count := (
+        SELECT COUNT(*) FROM user_tasks ut
+        WHERE ut.task_id = NEW.id AND ut.revoked_at IS NULL
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_tasks_recount_completions
+    BEFORE UPDATE OF completion_cap ON tasks
+    FOR EACH ROW
+    WHEN (OLD.completion_cap IS NULL AND NEW.completion_cap IS NOT NULL)
+    EXECUTE FUNCTION tasks_recount_completions();
+
+UPDATE tasks SET completions_count = 0 WHERE completion_cap IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_tasks_recount_completions ON tasks;
+DROP FUNCTION IF EXISTS tasks_recount_completions();
+
+UPDATE tasks t
+SET completions_count = (
+    SELECT COUNT(*) FROM user_tasks ut
+    WHERE ut.task_id = t.id AND ut.revoked_at IS NULL
+)
+WHERE t.completion_cap IS NULL;
+-- +goose StatementEnd
@@ -4,6 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
+
+	"service-boilerplate-go/internal/pkg/locale"
+	"service-boilerplate-go/internal/service/entities"
 
 	"github.com/joho/godotenv"
 )
@@ -13,9 +18,16 @@ const (
 )
 
 type Config struct {
-	auth     Auth
-	server   Server
-	postgres Postgres
+	auth        Auth
+	server      Server
+	postgres    Postgres
+	locale      Locale
+	webhooks    Webhooks
+	transfers   Transfers
+	expiry      Expiry
+	referrals   Referrals
+	fraud       Fraud
+	leaderboard Leaderboard
 }
 
 func (c Config) Server() Server {
@@ -28,6 +40,20 @@ func (c Config) Postgres() Postgres {
 
 func (c Config) Auth() Auth { return c.auth }
 
+func (c Config) Locale() Locale { return c.locale }
+
+func (c Config) Webhooks() Webhooks { return c.webhooks }
+
+func (c Config) Transfers() Transfers { return c.transfers }
+
+func (c Config) Expiry() Expiry { return c.expiry }
+
+func (c Config) Referrals() Referrals { return c.referrals }
+
+func (c Config) Fraud() Fraud { return c.fraud }
+
+func (c Config) Leaderboard() Leaderboard { return c.leaderboard }
+
 func Load() (config Config, err error) {
 	cfg, err := loadFromDotEnv()
 	if err != nil {
@@ -51,6 +77,26 @@ func loadFromDotEnv() (Config, error) {
 		return Config{}, fmt.Errorf("failed to load .env: %w", err)
 	}
 
+	transfers, err := loadTransfersFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	expiry, err := loadExpiryFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	referrals, err := loadReferralsFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	fraud, err := loadFraudFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
 	config := Config{
 		auth: Auth{
 			secret: os.Getenv("AUTH_SECRET"),
@@ -66,6 +112,19 @@ func loadFromDotEnv() (Config, error) {
 			password: os.Getenv("POSTGRES_PASSWORD"),
 			db:       os.Getenv("POSTGRES_DB"),
 		},
+		locale: Locale{
+			defaultLocale: os.Getenv("DEFAULT_LOCALE"),
+		},
+		webhooks: Webhooks{
+			partnerSecrets: os.Getenv("WEBHOOK_PARTNER_SECRETS"),
+		},
+		transfers: transfers,
+		expiry:    expiry,
+		referrals: referrals,
+		fraud:     fraud,
+		leaderboard: Leaderboard{
+			timezone: os.Getenv("LEADERBOARD_TIMEZONE"),
+		},
 	}
 
 	return config, nil
@@ -80,9 +139,38 @@ func overrideFromCommandLineFlags(baseConfig Config) (Config, error) {
 	postgresUser := flag.String("postgres-user", baseConfig.postgres.user, "PostgreSQL user")
 	postgresPassword := flag.String("postgres-password", baseConfig.postgres.password, "PostgreSQL password")
 	postgresDB := flag.String("postgres-db", baseConfig.postgres.db, "PostgreSQL database name")
+	defaultLocale := flag.String("default-locale", baseConfig.locale.defaultLocale, "Default locale for task texts")
+	transferDailyLimit := flag.Int("transfer-daily-limit", valueOrZero(baseConfig.transfers.dailyLimit), "Points a user can transfer per 24 hours (0 disables transfers)")
+	transferMinAccountAge := flag.Duration("transfer-min-account-age", valueOrZero(baseConfig.transfers.minAccountAge), "Minimum sender account age for transfers (0 disables the check)")
+	pointsExpiryDays := flag.String("points-expiry-days", baseConfig.expiry.days, "Points lifetime in days by credit source (source:days,...)")
+	pointsExpiryInterval := flag.Duration("points-expiry-interval", baseConfig.expiry.interval, "How often expired points are written off")
+	pointsExpiryWarning := flag.Duration("points-expiry-warning", baseConfig.expiry.warning, "How long before expiry points are reported as expiring soon")
+	referralRefereeBonus := flag.Int("referral-referee-bonus", baseConfig.referrals.refereeBonus, "Points credited to a user for setting a referrer")
+	referralReferrerBonus := flag.Int("referral-referrer-bonus", baseConfig.referrals.referrerBonus, "Points credited to the referrer for each referee")
+	referralCommissionRates := flag.String("referral-commission-rates", baseConfig.referrals.commissionRates, "Referral commission percent by level (10,5,...)")
+	referralReferrerAfterTasks := flag.Int("referral-referrer-after-tasks", baseConfig.referrals.referrerAfterTasks, "Tasks the referee must complete before the referrer bonus is credited")
+	referralRequireOlder := flag.Bool("referral-require-older-referrer", baseConfig.referrals.requireOlder, "Reject referrers registered after the user")
+	fraudScoreThreshold := flag.Int("fraud-score-threshold", baseConfig.fraud.scoreThreshold, "Referral fraud score that holds rewards for review (0 disables)")
+	fraudBurstWindow := flag.Duration("fraud-burst-window", baseConfig.fraud.burstWindow, "Window for counting signups under one referrer")
+	fraudBurstSignups := flag.Int("fraud-burst-signups", baseConfig.fraud.burstSignups, "Signups under one referrer within the window that count as a burst")
+	fraudTrustedProxies := flag.String("fraud-trusted-proxies", baseConfig.fraud.trustedProxies, "Proxy addresses or CIDRs whose X-Forwarded-For sets the signup IP (10.0.0.0/8,...)")
+	fraudIdleRatio := flag.Float64("fraud-idle-ratio", baseConfig.fraud.idleRatio, "Share of referees without own tasks that marks a referrer network as suspicious")
+	leaderboardTimezone := flag.String("leaderboard-timezone", baseConfig.leaderboard.timezone, "IANA timezone with a whole-hour UTC offset for daily, weekly and monthly leaderboards")
+	webhookPartnerSecrets := flag.String("webhook-partner-secrets", baseConfig.webhooks.partnerSecrets, "Partner webhook secrets (partner:secret,...)")
 
 	flag.Parse()
 
+	// нулевые лимиты переводов допустимы, поэтому флаги применяются, только если заданы явно
+	transfers := baseConfig.transfers
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "transfer-daily-limit":
+			transfers.dailyLimit = transferDailyLimit
+		case "transfer-min-account-age":
+			transfers.minAccountAge = transferMinAccountAge
+		}
+	})
+
 	config := Config{
 		auth: Auth{
 			secret: *authSecret,
@@ -98,11 +186,171 @@ func overrideFromCommandLineFlags(baseConfig Config) (Config, error) {
 			password: *postgresPassword,
 			db:       *postgresDB,
 		},
+		locale: Locale{
+			defaultLocale: *defaultLocale,
+		},
+		webhooks: Webhooks{
+			partnerSecrets: *webhookPartnerSecrets,
+		},
+		transfers: transfers,
+		expiry: Expiry{
+			days:     *pointsExpiryDays,
+			interval: *pointsExpiryInterval,
+			warning:  *pointsExpiryWarning,
+		},
+		referrals: Referrals{
+			refereeBonus:       *referralRefereeBonus,
+			referrerBonus:      *referralReferrerBonus,
+			referrerAfterTasks: *referralReferrerAfterTasks,
+			commissionRates:    *referralCommissionRates,
+			requireOlder:       *referralRequireOlder,
+		},
+		fraud: Fraud{
+			scoreThreshold: *fraudScoreThreshold,
+			burstWindow:    *fraudBurstWindow,
+			burstSignups:   *fraudBurstSignups,
+			idleRatio:      *fraudIdleRatio,
+			trustedProxies: *fraudTrustedProxies,
+		},
+		leaderboard: Leaderboard{
+			timezone: *leaderboardTimezone,
+		},
 	}
 
 	return config, nil
 }
 
+// loadTransfersFromEnv читает лимиты переводов; пустые значения оставляют значения по умолчанию,
+// а нулевые задаются явно
+func loadTransfersFromEnv() (Transfers, error) {
+	var transfers Transfers
+
+	if v := os.Getenv("TRANSFER_DAILY_LIMIT"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return Transfers{}, fmt.Errorf("invalid TRANSFER_DAILY_LIMIT: %w", err)
+		}
+		transfers.dailyLimit = &limit
+	}
+
+	if v := os.Getenv("TRANSFER_MIN_ACCOUNT_AGE"); v != "" {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return Transfers{}, fmt.Errorf("invalid TRANSFER_MIN_ACCOUNT_AGE: %w", err)
+		}
+		transfers.minAccountAge = &age
+	}
+
+	return transfers, nil
+}
+
+// loadExpiryFromEnv читает политику сгорания баллов; пустые значения оставляют значения по умолчанию
+func loadExpiryFromEnv() (Expiry, error) {
+	expiry := Expiry{
+		days: os.Getenv("POINTS_EXPIRY_DAYS"),
+	}
+
+	if v := os.Getenv("POINTS_EXPIRY_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return Expiry{}, fmt.Errorf("invalid POINTS_EXPIRY_INTERVAL: %w", err)
+		}
+		expiry.interval = interval
+	}
+
+	if v := os.Getenv("POINTS_EXPIRY_WARNING"); v != "" {
+		warning, err := time.ParseDuration(v)
+		if err != nil {
+			return Expiry{}, fmt.Errorf("invalid POINTS_EXPIRY_WARNING: %w", err)
+		}
+		expiry.warning = warning
+	}
+
+	return expiry, nil
+}
+
+// loadReferralsFromEnv читает бонусы за приглашения; пустые значения — без бонуса
+func loadReferralsFromEnv() (Referrals, error) {
+	referrals := Referrals{
+		commissionRates: os.Getenv("REFERRAL_COMMISSION_RATES"),
+	}
+
+	if v := os.Getenv("REFERRAL_REFEREE_BONUS"); v != "" {
+		bonus, err := strconv.Atoi(v)
+		if err != nil {
+			return Referrals{}, fmt.Errorf("invalid REFERRAL_REFEREE_BONUS: %w", err)
+		}
+		referrals.refereeBonus = bonus
+	}
+
+	if v := os.Getenv("REFERRAL_REFERRER_BONUS"); v != "" {
+		bonus, err := strconv.Atoi(v)
+		if err != nil {
+			return Referrals{}, fmt.Errorf("invalid REFERRAL_REFERRER_BONUS: %w", err)
+		}
+		referrals.referrerBonus = bonus
+	}
+
+	if v := os.Getenv("REFERRAL_REFERRER_AFTER_TASKS"); v != "" {
+		tasks, err := strconv.Atoi(v)
+		if err != nil {
+			return Referrals{}, fmt.Errorf("invalid REFERRAL_REFERRER_AFTER_TASKS: %w", err)
+		}
+		referrals.referrerAfterTasks = tasks
+	}
+
+	if v := os.Getenv("REFERRAL_REQUIRE_OLDER_REFERRER"); v != "" {
+		require, err := strconv.ParseBool(v)
+		if err != nil {
+			return Referrals{}, fmt.Errorf("invalid REFERRAL_REQUIRE_OLDER_REFERRER: %w", err)
+		}
+		referrals.requireOlder = require
+	}
+
+	return referrals, nil
+}
+
+// loadFraudFromEnv читает пороги проверки приглашений; пустые значения оставляют значения по умолчанию
+func loadFraudFromEnv() (Fraud, error) {
+	var fraud Fraud
+
+	if v := os.Getenv("FRAUD_SCORE_THRESHOLD"); v != "" {
+		threshold, err := strconv.Atoi(v)
+		if err != nil {
+			return Fraud{}, fmt.Errorf("invalid FRAUD_SCORE_THRESHOLD: %w", err)
+		}
+		fraud.scoreThreshold = threshold
+	}
+
+	if v := os.Getenv("FRAUD_BURST_WINDOW"); v != "" {
+		window, err := time.ParseDuration(v)
+		if err != nil {
+			return Fraud{}, fmt.Errorf("invalid FRAUD_BURST_WINDOW: %w", err)
+		}
+		fraud.burstWindow = window
+	}
+
+	if v := os.Getenv("FRAUD_BURST_SIGNUPS"); v != "" {
+		signups, err := strconv.Atoi(v)
+		if err != nil {
+			return Fraud{}, fmt.Errorf("invalid FRAUD_BURST_SIGNUPS: %w", err)
+		}
+		fraud.burstSignups = signups
+	}
+
+	if v := os.Getenv("FRAUD_IDLE_RATIO"); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return Fraud{}, fmt.Errorf("invalid FRAUD_IDLE_RATIO: %w", err)
+		}
+		fraud.idleRatio = ratio
+	}
+
+	fraud.trustedProxies = os.Getenv("FRAUD_TRUSTED_PROXIES")
+
+	return fraud, nil
+}
+
 func validateConfig(cfg Config) error {
 	if cfg.server.host == "" {
 		return fmt.Errorf("server host is required")
@@ -125,5 +373,61 @@ func validateConfig(cfg Config) error {
 	if cfg.postgres.db == "" {
 		return fmt.Errorf("postgres database name is required")
 	}
+	if cfg.locale.defaultLocale != "" {
+		if _, ok := locale.Normalize(cfg.locale.defaultLocale); !ok {
+			return fmt.Errorf("invalid default locale %q", cfg.locale.defaultLocale)
+		}
+	}
+	if cfg.transfers.dailyLimit != nil && *cfg.transfers.dailyLimit < 0 {
+		return fmt.Errorf("transfer daily limit must not be negative")
+	}
+	if cfg.transfers.minAccountAge != nil && *cfg.transfers.minAccountAge < 0 {
+		return fmt.Errorf("transfer min account age must not be negative")
+	}
+	if cfg.expiry.interval < 0 {
+		return fmt.Errorf("points expiry interval must not be negative")
+	}
+	if cfg.expiry.warning < 0 {
+		return fmt.Errorf("points expiry warning must not be negative")
+	}
+	if _, err := parseExpiryDays(cfg.expiry.days); err != nil {
+		return fmt.Errorf("invalid points expiry days: %w", err)
+	}
+	if cfg.referrals.refereeBonus < 0 || cfg.referrals.referrerBonus < 0 || cfg.referrals.referrerAfterTasks < 0 {
+		return fmt.Errorf("referral bonuses and task threshold must not be negative")
+	}
+	if _, err := parseCommissionRates(cfg.referrals.commissionRates); err != nil {
+		return fmt.Errorf("invalid referral commission rates: %w", err)
+	}
+	if cfg.fraud.scoreThreshold < 0 || cfg.fraud.burstWindow < 0 || cfg.fraud.burstSignups < 0 {
+		return fmt.Errorf("fraud score threshold, burst window and burst signups must not be negative")
+	}
+	if cfg.fraud.idleRatio < 0 || cfg.fraud.idleRatio > 1 {
+		return fmt.Errorf("fraud idle ratio must be between 0 and 1")
+	}
+	if _, err := parseTrustedProxies(cfg.fraud.trustedProxies); err != nil {
+		return fmt.Errorf("invalid fraud trusted proxies: %w", err)
+	}
+	if cfg.leaderboard.timezone != "" {
+		loc, err := time.LoadLocation(cfg.leaderboard.timezone)
+		if err != nil {
+			return fmt.Errorf("invalid leaderboard timezone: %w", err)
+		}
+		// рейтинги за период собираются из часовых корзин; проверяем переходы
+		// на летнее время за год назад и вперёд
+		now := time.Now()
+		if !entities.IsHourAlignedLocation(loc, now.AddDate(-1, 0, 0), now.AddDate(1, 0, 0)) {
+			return fmt.Errorf("invalid leaderboard timezone: %s is not offset from UTC by whole hours", cfg.leaderboard.timezone)
+		}
+	}
 	return nil
 }
+
+// valueOrZero возвращает значение необязательной настройки или нулевое значение, если она не задана
+func valueOrZero[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
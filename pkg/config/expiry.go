@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"service-boilerplate-go/internal/service/entities"
+)
+
+type Expiry struct {
+	days     string
+	interval time.Duration
+	warning  time.Duration
+}
+
+// Days возвращает срок жизни начислений в днях по источнику начисления.
+// Формат значения: "task_completed:365,transfer_in:90". Источники без срока не сгорают.
+// Некорректное значение и неизвестный источник отклоняются при загрузке конфига.
+func (e Expiry) Days() map[string]int {
+	days, _ := parseExpiryDays(e.days)
+	return days
+}
+
+// parseExpiryDays разбирает сроки жизни начислений по источникам.
+// Источник — причина проводки журнала, с которой начисляются баллы: опечатка
+// в названии иначе молча оставила бы такие баллы бессрочными.
+func parseExpiryDays(s string) (map[string]int, error) {
+	days := make(map[string]int)
+	if strings.TrimSpace(s) == "" {
+		return days, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		source, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		source = strings.TrimSpace(source)
+		if !ok || source == "" {
+			return nil, fmt.Errorf("%q: expected source:days", pair)
+		}
+		if !entities.LedgerReason(source).IsCredit() {
+			return nil, fmt.Errorf("%s: unknown credit source", source)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("%s: days must be positive", source)
+		}
+		days[source] = n
+	}
+	return days, nil
+}
+
+// Interval возвращает период запуска задачи сгорания баллов; 0 — значение по умолчанию
+func (e Expiry) Interval() time.Duration { return e.interval }
+
+// Warning возвращает, за сколько до сгорания баллы показываются как «скоро сгорят»; 0 — значение по умолчанию
+func (e Expiry) Warning() time.Duration { return e.warning }
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseExpiryDays(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    map[string]int
+		wantErr bool
+	}{
+		{value: "", want: map[string]int{}},
+		{value: "task_completed:365", want: map[string]int{"task_completed": 365}},
+		{value: " task_completed : 365 , transfer_in:90", want: map[string]int{"task_completed": 365, "transfer_in": 90}},
+		{value: "task_completed", wantErr: true},
+		{value: ":30", wantErr: true},
+		{value: "task_completed:year", wantErr: true},
+		{value: "task_completed:0", wantErr: true},
+		{value: "task_completed:-5", wantErr: true},
+		{value: "task_completed:30,", wantErr: true},
+		{value: "task_complete:30", wantErr: true},
+		{value: "redemption:30", wantErr: true},
+		{value: "referee_bonus:30,referral_commission:60", want: map[string]int{"referee_bonus": 30, "referral_commission": 60}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseExpiryDays(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseExpiryDays(%q) error = %v, wantErr %t", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !maps.Equal(got, tt.want) {
+			t.Errorf("parseExpiryDays(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+type Fraud struct {
+	scoreThreshold int
+	burstWindow    time.Duration
+	burstSignups   int
+	idleRatio      float64
+	trustedProxies string
+}
+
+// ScoreThreshold возвращает оценку, начиная с которой бонусы за приглашение
+// задерживаются до проверки администратором; 0 — проверка выключена
+func (f Fraud) ScoreThreshold() int { return f.scoreThreshold }
+
+// BurstWindow возвращает окно, в котором считаются приглашения одного реферера; 0 — значение по умолчанию
+func (f Fraud) BurstWindow() time.Duration { return f.burstWindow }
+
+// BurstSignups возвращает, сколько приглашений за окно считается всплеском; 0 — значение по умолчанию
+func (f Fraud) BurstSignups() int { return f.burstSignups }
+
+// IdleRatio возвращает долю приглашённых без собственных заданий,
+// начиная с которой сеть реферера считается подозрительной; 0 — значение по умолчанию
+func (f Fraud) IdleRatio() float64 { return f.idleRatio }
+
+// TrustedProxies возвращает сети прокси, которым доверяется X-Forwarded-For при определении
+// IP регистрации; пусто — заголовок игнорируется. Формат значения: "10.0.0.0/8,192.168.1.10".
+// Некорректное значение отклоняется при загрузке конфига.
+func (f Fraud) TrustedProxies() []netip.Prefix {
+	proxies, _ := parseTrustedProxies(f.trustedProxies)
+	return proxies
+}
+
+// parseTrustedProxies разбирает сети прокси; отдельный адрес считается сетью из одного адреса
+func parseTrustedProxies(s string) ([]netip.Prefix, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	proxies := make([]netip.Prefix, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if addr, err := netip.ParseAddr(part); err == nil {
+			proxies[i] = netip.PrefixFrom(addr, addr.BitLen())
+			continue
+		}
+		prefix, err := netip.ParsePrefix(part)
+		if err != nil {
+			return nil, fmt.Errorf("proxy %d: %w", i+1, err)
+		}
+		proxies[i] = prefix.Masked()
+	}
+	return proxies, nil
+}
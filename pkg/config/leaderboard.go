@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+type Leaderboard struct {
+	timezone string
+}
+
+// Location возвращает зону, в которой начинаются сутки, неделя и месяц рейтингов;
+// nil — локальная зона сервера. Некорректная зона отклоняется при загрузке конфига.
+func (l Leaderboard) Location() *time.Location {
+	if l.timezone == "" {
+		return nil
+	}
+	loc, _ := time.LoadLocation(l.timezone)
+	return loc
+}
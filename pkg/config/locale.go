@@ -0,0 +1,15 @@
+package config
+
+import "service-boilerplate-go/internal/pkg/locale"
+
+type Locale struct {
+	defaultLocale string
+}
+
+// Default возвращает локаль, на которую откатываются тексты без перевода.
+// Значение нормализуется так же, как Accept-Language: "ru_RU" → "ru-ru".
+// Некорректное значение отклоняется при загрузке конфига.
+func (l Locale) Default() string {
+	tag, _ := locale.Normalize(l.defaultLocale)
+	return tag
+}
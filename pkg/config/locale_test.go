@@ -0,0 +1,21 @@
+package config
+
+import "testing"
+
+func TestLocaleDefaultIsNormalized(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "ru", want: "ru"},
+		{value: "ru_RU", want: "ru-ru"},
+		{value: " EN-us ", want: "en-us"},
+	}
+
+	for _, tt := range tests {
+		if got := (Locale{defaultLocale: tt.value}).Default(); got != tt.want {
+			t.Errorf("Locale{%q}.Default() = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Referrals struct {
+	refereeBonus       int
+	referrerBonus      int
+	referrerAfterTasks int
+	commissionRates    string
+	requireOlder       bool
+}
+
+// RefereeBonus возвращает бонус приглашённому за указание реферера
+func (r Referrals) RefereeBonus() int { return r.refereeBonus }
+
+// ReferrerBonus возвращает бонус рефереру за приглашённого
+func (r Referrals) ReferrerBonus() int { return r.referrerBonus }
+
+// ReferrerAfterTasks возвращает, сколько заданий должен выполнить приглашённый,
+// прежде чем реферер получит бонус; 0 — бонус начисляется сразу
+func (r Referrals) ReferrerAfterTasks() int { return r.referrerAfterTasks }
+
+// RequireOlderReferrer сообщает, нужно ли отклонять рефереров, зарегистрированных позже приглашённого
+func (r Referrals) RequireOlderReferrer() bool { return r.requireOlder }
+
+// CommissionRates возвращает комиссии реферерам в процентах по уровням, начиная с прямого реферера.
+// Формат значения: "10,5" — 10% прямому рефереру и 5% его рефереру.
+// Некорректное значение отклоняется при загрузке конфига.
+func (r Referrals) CommissionRates() []float64 {
+	rates, _ := parseCommissionRates(r.commissionRates)
+	return rates
+}
+
+// parseCommissionRates разбирает проценты комиссий по уровням
+func parseCommissionRates(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	rates := make([]float64, len(parts))
+	for i, part := range parts {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: %w", i+1, err)
+		}
+		if rate < 0 || rate > 100 {
+			return nil, fmt.Errorf("level %d: rate must be between 0 and 100", i+1)
+		}
+		rates[i] = rate
+	}
+	return rates, nil
+}
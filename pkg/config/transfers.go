@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type Transfers struct {
+	dailyLimit    *int
+	minAccountAge *time.Duration
+}
+
+// DailyLimit возвращает, сколько баллов пользователь может перевести за сутки;
+// nil — значение по умолчанию, 0 — переводы запрещены
+func (t Transfers) DailyLimit() *int { return t.dailyLimit }
+
+// MinAccountAge возвращает минимальный возраст аккаунта отправителя;
+// nil — значение по умолчанию, 0 — без ограничения
+func (t Transfers) MinAccountAge() *time.Duration { return t.minAccountAge }
@@ -0,0 +1,21 @@
+package config
+
+import "strings"
+
+type Webhooks struct {
+	partnerSecrets string
+}
+
+// PartnerSecrets возвращает секреты подписи партнёров.
+// Формат значения: "partner1:secret1,partner2:secret2".
+func (w Webhooks) PartnerSecrets() map[string]string {
+	secrets := make(map[string]string)
+	for _, pair := range strings.Split(w.partnerSecrets, ",") {
+		partner, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || partner == "" || secret == "" {
+			continue
+		}
+		secrets[partner] = secret
+	}
+	return secrets
+}